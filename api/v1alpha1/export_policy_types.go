@@ -3,6 +3,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -40,15 +41,37 @@ type ExportPolicySpec struct {
 // ExportPolicyStatus defines the observed state of ExportPolicy.
 type ExportPolicyStatus struct {
 	// Provides summary status information on the export policy as a whole. Review
-	// the sink status information for detailed information on each sink.
+	// the source and sink status information for detailed information on each
+	// source and sink.
 	//
-	// Known condition types are: "Ready"
+	// Known condition types are: "Ready", "Programmed"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// The generation of the export policy whose configuration was last
+	// confirmed to be running in the telemetry exporters.
+	ProgrammedGeneration int64 `json:"programmedGeneration,omitempty"`
+
+	// Provides status information on each source that's configured.
+	Sources []SourceStatus `json:"sources,omitempty"`
+
 	// Provides status information on each sink that's configured.
 	Sinks []SinkStatus `json:"sinks,omitempty"`
 }
 
+// SourceStatus provides status information on the current status of a source.
+// This can be used to determine whether a source is configured correctly.
+type SourceStatus struct {
+	// The name of the corresponding source configuration in the spec of the
+	// export policy.
+	Name string `json:"name"`
+	// Provides status information on the current status of the source. Sources
+	// that are not accepted or not referenced by any sinks will not export any
+	// telemetry data.
+	//
+	// Known condition types are: "Accepted", "Referenced"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // SinkStatus provides status information on the current status of a sink. This
 // can be used to determine whether a sink is configured correctly and is
 // exporting telemetry data.
@@ -60,8 +83,30 @@ type SinkStatus struct {
 	// used to determine whether a sink is configured correctly and is exporting
 	// telemetry data.
 	//
-	// Known condition types are: "Ready"
+	// Known condition types are: "Accepted", "Healthy", "CardinalityLimited"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last time the sink was observed successfully sending telemetry data
+	// to its target.
+	//
+	// +optional
+	LastSentTime *metav1.Time `json:"lastSentTime,omitempty"`
+
+	// The rate of errors per second reported by the sink over the last five
+	// minutes, rounded to a single significant digit.
+	//
+	// +optional
+	ErrorRate string `json:"errorRate,omitempty"`
+
+	// The rate of events per second that the sink dropped over the last five
+	// minutes because they could not be delivered to its target, such as when
+	// all retry attempts failed, rounded to a single significant digit. The
+	// dropped events are not retained. The telemetry exporter can't route
+	// events to another sink after their delivery failed, so there's no audit
+	// trail of the individual events that never reached the target.
+	//
+	// +optional
+	DroppedEventRate string `json:"droppedEventRate,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -106,6 +151,187 @@ type MetricSource struct {
 	//
 	// See: https://docs.victoriametrics.com/metricsql/
 	MetricsQL string `json:"metricsql,omitempty"`
+
+	// The Selector option allows the user to select the metric data that should
+	// be published by the export policy without writing a metricsql query. All
+	// of the configured options must match for a metric to be selected.
+	Selector *MetricSelector `json:"selector,omitempty"`
+
+	// Aggregates the selected metrics over a rollup window before they're
+	// published. Each window publishes a single aggregated sample for every
+	// group of labels instead of every raw series.
+	Aggregation *MetricAggregation `json:"aggregation,omitempty"`
+}
+
+// The function used to aggregate metrics. Increase is used for counters, the
+// other functions are used for gauges.
+//
+// +kubebuilder:validation:Enum=Increase;Sum;Avg;Max
+type MetricAggregationFunction string
+
+const (
+	// Sums how much each counter increased in the window. The aggregates are
+	// published as counters that are incremented by the sum, so counter resets
+	// of the selected series are accounted for.
+	MetricAggregationFunctionIncrease MetricAggregationFunction = "Increase"
+	// Sums the latest value of each gauge in the window, such as the number of
+	// open connections of each gateway. The aggregates are published as gauges.
+	MetricAggregationFunctionSum MetricAggregationFunction = "Sum"
+	// Averages the values of each gauge in the window. The aggregates are
+	// published as gauges.
+	MetricAggregationFunctionAvg MetricAggregationFunction = "Avg"
+	// Uses the maximum value of each gauge in the window. The aggregates are
+	// published as gauges.
+	MetricAggregationFunctionMax MetricAggregationFunction = "Max"
+)
+
+// Configures how metrics are aggregated before they're published.
+type MetricAggregation struct {
+	// The rollup window the metrics are aggregated over. A new aggregate is
+	// published at the end of every window. Must be a whole number of seconds
+	// between 30s and 1h. Defaults to 1m.
+	//
+	// +kubebuilder:default="1m"
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// The function used to aggregate the metrics. Use Increase to aggregate
+	// counters, since the value of a counter is only meaningful compared to its
+	// earlier values. Use Sum, Avg or Max to aggregate gauges.
+	//
+	// +kubebuilder:validation:Required
+	Function MetricAggregationFunction `json:"function"`
+
+	// The labels the metrics are grouped by. All other labels are removed from
+	// the aggregated metrics. Metrics are always grouped by their name.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	By []string `json:"by,omitempty"`
+}
+
+// Selects metric data using the resources the metrics were reported for, the
+// metric names and the labels of the metrics.
+type MetricSelector struct {
+	// Selects metrics reported by the service (e.g. networking.miloapis.com).
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// Selects metrics reported for any of the resource kinds (e.g. Gateway).
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	ResourceKinds []string `json:"resourceKinds,omitempty"`
+
+	// Selects metrics reported for any of the resources with the names.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	ResourceNames []string `json:"resourceNames,omitempty"`
+
+	// Selects metrics reported for resources in any of the namespaces.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	ResourceNamespaces []string `json:"resourceNamespaces,omitempty"`
+
+	// Selects metrics with a name that matches any of the glob patterns. The
+	// `*` character matches any sequence of characters and the `?` character
+	// matches a single character (e.g. gateway_request_*).
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	MetricNames []string `json:"metricNames,omitempty"`
+
+	// Selects metrics with labels that match all of the label matchers.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	LabelMatchers []LabelMatcher `json:"labelMatchers,omitempty"`
+}
+
+// The operator used to match the value of a label.
+//
+// +kubebuilder:validation:Enum=Equal;NotEqual;RegexMatch;NotRegexMatch
+type LabelMatchOperator string
+
+const (
+	// The label value must be equal to the value.
+	LabelMatchOperatorEqual LabelMatchOperator = "Equal"
+	// The label value must not be equal to the value.
+	LabelMatchOperatorNotEqual LabelMatchOperator = "NotEqual"
+	// The label value must match the regular expression.
+	LabelMatchOperatorRegexMatch LabelMatchOperator = "RegexMatch"
+	// The label value must not match the regular expression.
+	LabelMatchOperatorNotRegexMatch LabelMatchOperator = "NotRegexMatch"
+)
+
+// Matches the value of a metric label.
+type LabelMatcher struct {
+	// The name of the label.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Label string `json:"label"`
+
+	// The operator used to match the label value. Defaults to Equal.
+	//
+	// +kubebuilder:default=Equal
+	Operator LabelMatchOperator `json:"operator,omitempty"`
+
+	// The value, or regular expression, the label value is matched against.
+	Value string `json:"value"`
+}
+
+// A log source configures the log data that should be exported to the
+// configured sinks. Logs are exported in 30 second windows about a minute after
+// they're emitted. Logs that are ingested more than a minute after their
+// timestamp are not exported.
+type LogSource struct {
+	// The LogsQL option allows the user to provide a LogsQL filter that can be
+	// used to select the logs that should be published by the export policy.
+	// Only filters are supported, LogsQL pipes can not be used.
+	//
+	// Here's an example of a LogsQL filter that will publish error logs from
+	// gateways:
+	//
+	// ``` resource_kind:="Gateway" AND level:="error" ```
+	//
+	// See: https://docs.victoriametrics.com/victorialogs/logsql/
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	LogsQL string `json:"logsql"`
+}
+
+// A trace source configures the spans that should be exported to the configured
+// sinks. All of the project's spans are selected when no options are provided.
+type TraceSource struct {
+	// Selects spans that were emitted by one of the services, matched against
+	// the `service.name` resource attribute (e.g. networking.miloapis.com).
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	ServiceNames []string `json:"serviceNames,omitempty"`
+
+	// Selects spans from resources that have all of the resource attributes.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=key
+	ResourceAttributes []ResourceAttributeMatch `json:"resourceAttributes,omitempty"`
+}
+
+// Matches a resource attribute of a span.
+type ResourceAttributeMatch struct {
+	// The key of the resource attribute (e.g. resource.kind).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Key string `json:"key"`
+
+	// The value the resource attribute must be equal to.
+	//
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
 }
 
 // Defines how the export policy should source telemetry data from resources on
@@ -123,11 +349,22 @@ type TelemetrySource struct {
 	// Configures how the telemetry source should retrieve metric data from the
 	// Datum Cloud platform.
 	Metrics *MetricSource `json:"metrics,omitempty"`
+
+	// Configures how the telemetry source should retrieve log data from the
+	// Datum Cloud platform.
+	Logs *LogSource `json:"logs,omitempty"`
+
+	// Configures how the telemetry source should select trace data from the
+	// Datum Cloud platform. Traces can only be published to OpenTelemetry sinks,
+	// and trace sources are only accepted when the platform's trace receiver is
+	// enabled.
+	Traces *TraceSource `json:"traces,omitempty"`
 }
 
-// Configures how telemetry data should be sent to a third-party platform. As of
-// now there are no guarantees around delivery of telemetry data, especially if
-// the sink's endpoint is unavailable.
+// Configures how telemetry data should be sent to a third-party platform. By
+// default telemetry data is buffered in memory and may be lost if the sink's
+// endpoint is unavailable for an extended period of time. A disk buffer can be
+// configured to retain telemetry data while the endpoint is unavailable.
 type TelemetrySink struct {
 	// A name provided to the telemetry sink that's unique within the export
 	// policy.
@@ -149,6 +386,242 @@ type TelemetrySink struct {
 	//
 	// +kubebuilder:validation:Required
 	Target *SinkTarget `json:"target"`
+
+	// Transforms that are applied to the telemetry before it's published to
+	// the sink. Transforms can only be configured for sinks that publish
+	// metrics.
+	Transforms *SinkTransforms `json:"transforms,omitempty"`
+
+	// Limits the number of distinct values of each metric label that are
+	// published to the sink. The limit is applied after the transforms. Can
+	// only be configured for sinks that publish metrics.
+	CardinalityLimit *CardinalityLimit `json:"cardinalityLimit,omitempty"`
+
+	// Configures how telemetry data is buffered before it's sent to the sink.
+	// By default, up to 500 events are buffered in memory and the sources
+	// wait for space in the buffer when it's full.
+	Buffer *SinkBuffer `json:"buffer,omitempty"`
+
+	// Enables end-to-end acknowledgements for the sink. Sources that support
+	// acknowledgements wait for the sink to confirm telemetry data was
+	// delivered, or written to a disk buffer, before it's acknowledged.
+	Acknowledgements bool `json:"acknowledgements,omitempty"`
+}
+
+// The type of buffer used by a sink.
+//
+// +kubebuilder:validation:Enum=Memory;Disk
+type SinkBufferType string
+
+const (
+	// Telemetry data is buffered in memory and lost if the exporter restarts.
+	SinkBufferTypeMemory SinkBufferType = "Memory"
+	// Telemetry data is buffered on disk and retained if the exporter
+	// restarts.
+	SinkBufferTypeDisk SinkBufferType = "Disk"
+)
+
+// What happens when a sink's buffer is full.
+//
+// +kubebuilder:validation:Enum=Block;DropNewest
+type SinkBufferWhenFull string
+
+const (
+	// Waits for space in the buffer. Sources will stop collecting telemetry
+	// data until there's space in the buffer.
+	SinkBufferWhenFullBlock SinkBufferWhenFull = "Block"
+	// Drops new telemetry data until there's space in the buffer.
+	SinkBufferWhenFullDropNewest SinkBufferWhenFull = "DropNewest"
+)
+
+// Configures the buffer of a sink.
+type SinkBuffer struct {
+	// The type of buffer. Defaults to Memory.
+	//
+	// +kubebuilder:default=Memory
+	Type SinkBufferType `json:"type,omitempty"`
+
+	// The maximum number of events that can be buffered in memory. Only used
+	// by memory buffers. Defaults to 500.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	MaxEvents int32 `json:"maxEvents,omitempty"`
+
+	// The maximum size of the buffer on disk. Only used by disk buffers and
+	// must be between 256Mi and 2Gi. The disk buffers of an export policy's
+	// sinks can't be larger than 4Gi in total.
+	//
+	// +kubebuilder:validation:XValidation:rule="quantity(string(self)).compareTo(quantity('2Gi')) <= 0",message="maxSize can not be larger than 2Gi"
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+
+	// What happens when the buffer is full. Defaults to Block. Sinks that
+	// publish traces always drop new spans when the buffer is full so they
+	// can't block the platform's trace receiver.
+	//
+	// +kubebuilder:default=Block
+	WhenFull SinkBufferWhenFull `json:"whenFull,omitempty"`
+}
+
+// What happens to a metric with a label value that exceeds the cardinality
+// limit.
+//
+// +kubebuilder:validation:Enum=DropTag;DropEvent
+type CardinalityLimitMode string
+
+const (
+	// The label is removed from the metric and the metric is published.
+	CardinalityLimitModeDropTag CardinalityLimitMode = "DropTag"
+	// The metric is not published.
+	CardinalityLimitModeDropEvent CardinalityLimitMode = "DropEvent"
+)
+
+// Configures the cardinality limit of a sink.
+type CardinalityLimit struct {
+	// The maximum number of distinct values of each label that are published
+	// to the sink.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000
+	ValueLimit int32 `json:"valueLimit"`
+
+	// What happens to a metric with a label value that exceeds the limit.
+	// Defaults to DropTag.
+	//
+	// +kubebuilder:default=DropTag
+	Mode CardinalityLimitMode `json:"mode,omitempty"`
+}
+
+// Configures how metrics are transformed before they're published to a sink.
+// Metrics are filtered by name first, then the labels of the remaining metrics
+// are changed and finally the relabel configs are applied.
+type SinkTransforms struct {
+	// Only publishes metrics with a name that matches any of the regular
+	// expressions. The regular expressions must match the entire metric name.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	IncludeMetrics []string `json:"includeMetrics,omitempty"`
+
+	// Does not publish metrics with a name that matches any of the regular
+	// expressions. The regular expressions must match the entire metric name.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	ExcludeMetrics []string `json:"excludeMetrics,omitempty"`
+
+	// Removes the labels from the published metrics.
+	//
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=set
+	DropLabels []string `json:"dropLabels,omitempty"`
+
+	// Removes all labels from the published metrics except for the labels in
+	// the list. Can not be configured with dropLabels.
+	//
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=set
+	KeepLabels []string `json:"keepLabels,omitempty"`
+
+	// Adds static labels to the published metrics (e.g. env=prod). Existing
+	// labels with the same name are replaced.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	AddLabels []StaticLabel `json:"addLabels,omitempty"`
+
+	// Relabels the published metrics following the semantics of Prometheus
+	// relabel configs. The relabel configs are applied in order. The metric
+	// name can be read and changed using the `__name__` label.
+	//
+	// See: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config
+	//
+	// +kubebuilder:validation:MaxItems=20
+	RelabelConfigs []RelabelConfig `json:"relabelConfigs,omitempty"`
+}
+
+// The action performed by a relabel config.
+//
+// +kubebuilder:validation:Enum=replace;keep;drop;labelmap;labeldrop;labelkeep;hashmod
+type RelabelAction string
+
+const (
+	// Sets the target label to the replacement when the regex matches the
+	// concatenated source label values.
+	RelabelActionReplace RelabelAction = "replace"
+	// Drops metrics where the regex doesn't match the concatenated source
+	// label values.
+	RelabelActionKeep RelabelAction = "keep"
+	// Drops metrics where the regex matches the concatenated source label
+	// values.
+	RelabelActionDrop RelabelAction = "drop"
+	// Copies the value of labels with names that match the regex to labels
+	// named by the replacement.
+	RelabelActionLabelMap RelabelAction = "labelmap"
+	// Removes labels with names that match the regex.
+	RelabelActionLabelDrop RelabelAction = "labeldrop"
+	// Removes labels with names that don't match the regex.
+	RelabelActionLabelKeep RelabelAction = "labelkeep"
+	// Sets the target label to the modulus of a hash of the concatenated source
+	// label values.
+	RelabelActionHashMod RelabelAction = "hashmod"
+)
+
+// Configures how the labels of a metric are rewritten.
+type RelabelConfig struct {
+	// The labels whose values are concatenated using the separator and matched
+	// against the regex.
+	//
+	// +kubebuilder:validation:MaxItems=10
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// The separator placed between the concatenated source label values.
+	// Defaults to `;`.
+	//
+	// +kubebuilder:default=";"
+	Separator string `json:"separator,omitempty"`
+
+	// The label that's set by the replace and hashmod actions.
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// The regular expression matched against the concatenated source label
+	// values, or the label names for the labelmap, labeldrop and labelkeep
+	// actions. The regular expression must match the entire value. Defaults to
+	// `(.*)`.
+	//
+	// +kubebuilder:default="(.*)"
+	Regex string `json:"regex,omitempty"`
+
+	// The modulus used by the hashmod action.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	Modulus int64 `json:"modulus,omitempty"`
+
+	// The replacement value used by the replace and labelmap actions. Capture
+	// groups of the regex can be referenced (e.g. $1). Defaults to `$1`.
+	//
+	// +kubebuilder:default="$1"
+	Replacement *string `json:"replacement,omitempty"`
+
+	// The action performed by the relabel config. Defaults to replace.
+	//
+	// +kubebuilder:default=replace
+	Action RelabelAction `json:"action,omitempty"`
+}
+
+// A label with a static value.
+type StaticLabel struct {
+	// The name of the label.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// The value of the label.
+	//
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
 }
 
 // Configures the target of the telemetry sink. The target defines the protocol
@@ -158,6 +631,30 @@ type SinkTarget struct {
 	// Configures the export policy to publish telemetry using the Prometheus
 	// Remote Write protocol.
 	PrometheusRemoteWrite *PrometheusRemoteWriteSink `json:"prometheusRemoteWrite,omitempty"`
+
+	// Configures the export policy to publish telemetry using the OpenTelemetry
+	// Protocol (OTLP).
+	OpenTelemetry *OpenTelemetrySink `json:"openTelemetry,omitempty"`
+
+	// Configures the export policy to publish metrics to Datadog.
+	DatadogMetrics *DatadogMetricsSink `json:"datadogMetrics,omitempty"`
+
+	// Configures the export policy to publish logs to Grafana Loki.
+	Loki *LokiSink `json:"loki,omitempty"`
+
+	// Configures the export policy to archive metrics or logs in an S3
+	// compatible object storage bucket.
+	ObjectStorage *ObjectStorageSink `json:"objectStorage,omitempty"`
+
+	// Configures the export policy to stream metrics or logs to Apache Kafka.
+	Kafka *KafkaSink `json:"kafka,omitempty"`
+
+	// Configures the export policy to publish logs to a Splunk HTTP Event
+	// Collector.
+	SplunkHEC *SplunkHECSink `json:"splunkHec,omitempty"`
+
+	// Configures the export policy to publish logs to Elasticsearch.
+	Elasticsearch *ElasticsearchSink `json:"elasticsearch,omitempty"`
 }
 
 // References a secret in the same namespace as the entity defining the
@@ -169,6 +666,34 @@ type LocalSecretReference struct {
 	Name string `json:"name"`
 }
 
+// References a key of a secret in the same namespace as the entity defining
+// the reference.
+type LocalSecretKeyReference struct {
+	// The name of the secret
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The key of the secret to select from.
+	//
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// References a key of a config map in the same namespace as the entity
+// defining the reference.
+type LocalConfigMapKeyReference struct {
+	// The name of the config map
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The key of the config map to select from.
+	//
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
 // Configures how the sink should use Basic Auth for authenticating with a
 // telemetry endpoint.
 type BasicAuthAuthentication struct {
@@ -179,15 +704,93 @@ type BasicAuthAuthentication struct {
 	SecretRef LocalSecretReference `json:"secretRef"`
 }
 
+// Configures how the sink should use a bearer token for authenticating with a
+// telemetry endpoint.
+type BearerTokenAuthentication struct {
+	// Selects the key of a secret that contains the bearer token to add to the
+	// authorization header.
+	//
+	// +kubebuilder:validation:Required
+	SecretKeyRef LocalSecretKeyReference `json:"secretKeyRef"`
+}
+
+// Configures how the sink should use the OAuth2 client credentials flow to
+// retrieve an access token for authenticating with a telemetry endpoint. The
+// access token is refreshed by the control plane before it expires.
+type OAuth2Authentication struct {
+	// The URL of the authorization server's token endpoint. The token endpoint
+	// must use https and resolve to a public address.
+	//
+	// +kubebuilder:validation:Required
+	TokenURL string `json:"tokenURL"`
+
+	// Configures which secret is used to retrieve the client credentials. The
+	// secret must contain the `client-id` and `client-secret` keys.
+	//
+	// +kubebuilder:validation:Required
+	ClientSecretRef LocalSecretReference `json:"clientSecretRef"`
+
+	// The scopes that will be requested for the access token.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	Scopes []string `json:"scopes,omitempty"`
+
+	// The audience that will be requested for the access token.
+	Audience string `json:"audience,omitempty"`
+}
+
+// Configures how the sink should sign requests using AWS Signature Version 4.
+type AWSSigV4Authentication struct {
+	// The AWS region of the endpoint (e.g. us-east-1).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-[0-9]+$`
+	Region string `json:"region"`
+
+	// The name of the AWS service requests are signed for. Only the Amazon
+	// Managed Service for Prometheus (`aps`) service is currently supported.
+	//
+	// +kubebuilder:validation:Enum=aps
+	// +kubebuilder:default=aps
+	Service string `json:"service,omitempty"`
+
+	// Configures which secret is used to retrieve the AWS access keys. The
+	// secret must contain the `access-key-id` and `secret-access-key` keys.
+	//
+	// +kubebuilder:validation:Required
+	SecretRef LocalSecretReference `json:"secretRef"`
+
+	// The ARN of an IAM role to assume using the access keys before signing
+	// requests.
+	//
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	AssumeRoleARN string `json:"assumeRoleARN,omitempty"`
+}
+
 // Configures how the sink will authenticate with the configured endpoint. These
 // options are mutually exclusive.
 type Authentication struct {
 	// Configures the sink to use basic auth to authenticate with the configured
 	// endpoint.
 	BasicAuth *BasicAuthAuthentication `json:"basicAuth,omitempty"`
+
+	// Configures the sink to use a bearer token to authenticate with the
+	// configured endpoint.
+	BearerToken *BearerTokenAuthentication `json:"bearerToken,omitempty"`
+
+	// Configures the sink to retrieve an access token using the OAuth2 client
+	// credentials flow to authenticate with the configured endpoint.
+	OAuth2 *OAuth2Authentication `json:"oauth2,omitempty"`
+
+	// Configures the sink to sign requests with AWS Signature Version 4 to
+	// authenticate with the configured endpoint. This is only supported by
+	// Prometheus Remote Write sinks (e.g. Amazon Managed Service for
+	// Prometheus).
+	AWSSigV4 *AWSSigV4Authentication `json:"awsSigV4,omitempty"`
 }
 
-// Configures how the sink should send data to a OTLP HTTP endpoint.
+// Configures how the sink should send data to a Prometheus Remote Write
+// endpoint.
 type PrometheusRemoteWriteSink struct {
 	// Configures how the sink should authenticate with the HTTP endpoint.
 	Authentication *Authentication `json:"authentication,omitempty"`
@@ -197,6 +800,17 @@ type PrometheusRemoteWriteSink struct {
 	// +kubebuilder:validation:Required
 	Endpoint string `json:"endpoint"`
 
+	// Additional headers that will be added to every request sent to the
+	// endpoint (e.g. X-Scope-OrgID).
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Headers []HTTPHeader `json:"headers,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoint.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
 	// Configures how telemetry data should be batched before sending to the sink.
 	// By default, the sink will batch telemetry data every 5 seconds or when
 	// the batch size reaches 500 entries, whichever comes first.
@@ -213,6 +827,524 @@ type PrometheusRemoteWriteSink struct {
 	Retry Retry `json:"retry"`
 }
 
+// Configures how the sink should send data to an endpoint that supports the
+// OpenTelemetry Protocol (OTLP). Telemetry is published using OTLP over HTTP.
+// OTLP over gRPC is not supported since the telemetry exporter can only
+// publish OTLP over HTTP, so collectors must enable their OTLP HTTP receiver.
+type OpenTelemetrySink struct {
+	// Configures the sink to send telemetry to an OTLP endpoint over HTTP.
+	//
+	// +kubebuilder:validation:Required
+	HTTP *OpenTelemetryHTTPSink `json:"http"`
+}
+
+// The encoding used to send telemetry data to an OTLP HTTP endpoint. Only the
+// binary protobuf encoding is supported.
+//
+// +kubebuilder:validation:Enum=Protobuf
+type OpenTelemetryEncoding string
+
+const (
+	// Telemetry data is encoded using the binary protobuf encoding.
+	OpenTelemetryEncodingProtobuf OpenTelemetryEncoding = "Protobuf"
+)
+
+// Configures how the sink should send data to an OTLP HTTP endpoint.
+type OpenTelemetryHTTPSink struct {
+	// Configures how the sink should authenticate with the HTTP endpoint.
+	Authentication *Authentication `json:"authentication,omitempty"`
+
+	// The URL of the OTLP HTTP endpoint that telemetry data will be published
+	// to, including the signal path (e.g. https://api.honeycomb.io/v1/metrics).
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Additional headers that will be added to every request sent to the
+	// endpoint.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Headers []HTTPHeader `json:"headers,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoint.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// The encoding used when sending telemetry data to the endpoint. Defaults
+	// to the binary protobuf encoding, which is the only supported encoding.
+	//
+	// +kubebuilder:default=Protobuf
+	Encoding OpenTelemetryEncoding `json:"encoding,omitempty"`
+
+	// Configures how telemetry data should be batched before sending to the sink.
+	// By default, the sink will batch telemetry data every 5 seconds or when
+	// the batch size reaches 500 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5s", maxSize: 500}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to send
+	// requests to the sink's endpoint. There's no guarantees that the export
+	// policy will retry until success if the endpoint is not available or
+	// configured incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// Configures the TLS settings a sink uses when connecting to an endpoint.
+//
+// The minimum TLS version can't be configured. The telemetry exporter doesn't
+// support restricting the TLS versions a sink negotiates, so sinks accept any
+// TLS version the exporter supports. Endpoints that require a minimum TLS
+// version must enforce it themselves.
+type TLSConfig struct {
+	// Configures the certificate authorities that are trusted when verifying
+	// the endpoint's certificate. The system's trusted certificate authorities
+	// are used when this is not configured.
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+
+	// References a secret containing the client certificate and key that will
+	// be presented to the endpoint. Secret must be a `kubernetes.io/tls` type.
+	ClientCertificate *LocalSecretReference `json:"clientCertificate,omitempty"`
+
+	// Overrides the server name used for Server Name Indication (SNI) and
+	// verifying the endpoint's certificate. Defaults to the host of the
+	// endpoint.
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// Configures where a PEM encoded bundle of certificate authorities is
+// retrieved from. These options are mutually exclusive.
+type CABundleSource struct {
+	// Selects a key of a secret that contains the certificate authorities.
+	SecretKeyRef *LocalSecretKeyReference `json:"secretKeyRef,omitempty"`
+
+	// Selects a key of a config map that contains the certificate authorities.
+	ConfigMapKeyRef *LocalConfigMapKeyReference `json:"configMapKeyRef,omitempty"`
+}
+
+// Configures an HTTP header that is added to requests sent to a sink.
+type HTTPHeader struct {
+	// The name of the HTTP header.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	Name string `json:"name"`
+
+	// The literal value of the HTTP header. Only one of value or secretKeyRef
+	// can be configured.
+	Value string `json:"value,omitempty"`
+
+	// Selects the key of a secret that contains the value of the HTTP header.
+	// Only one of value or secretKeyRef can be configured.
+	SecretKeyRef *LocalSecretKeyReference `json:"secretKeyRef,omitempty"`
+}
+
+// The Datadog site that telemetry data is published to.
+//
+// See: https://docs.datadoghq.com/getting_started/site/
+//
+// +kubebuilder:validation:Enum=US1;US3;US5;EU1;AP1;US1-FED
+type DatadogSite string
+
+const (
+	DatadogSiteUS1    DatadogSite = "US1"
+	DatadogSiteUS3    DatadogSite = "US3"
+	DatadogSiteUS5    DatadogSite = "US5"
+	DatadogSiteEU1    DatadogSite = "EU1"
+	DatadogSiteAP1    DatadogSite = "AP1"
+	DatadogSiteUS1FED DatadogSite = "US1-FED"
+)
+
+// Configures how the sink should send metrics to Datadog.
+type DatadogMetricsSink struct {
+	// Selects the key of a secret that contains the Datadog API key used to
+	// publish metrics.
+	//
+	// +kubebuilder:validation:Required
+	APIKeySecretRef LocalSecretKeyReference `json:"apiKeySecretRef"`
+
+	// The Datadog site that the organization is hosted on. Defaults to the US1
+	// site.
+	//
+	// +kubebuilder:default=US1
+	Site DatadogSite `json:"site,omitempty"`
+
+	// Configures how telemetry data should be batched before sending to the sink.
+	// By default, the sink will batch telemetry data every 5 seconds or when
+	// the batch size reaches 500 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5s", maxSize: 500}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to send
+	// requests to the sink's endpoint. There's no guarantees that the export
+	// policy will retry until success if the endpoint is not available or
+	// configured incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// Configures how the sink should send logs to a Grafana Loki endpoint.
+type LokiSink struct {
+	// Configures how the sink should authenticate with the HTTP endpoint.
+	Authentication *Authentication `json:"authentication,omitempty"`
+
+	// The base URL of the Loki endpoint that logs will be published to (e.g.
+	// https://logs-prod-us-central1.grafana.net). The push API path is added
+	// automatically.
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// The tenant ID to publish logs for when Loki is running in multi-tenant
+	// mode. The value can reference fields of the log event using templates
+	// (e.g. {{ resource_namespace }}).
+	TenantID string `json:"tenantID,omitempty"`
+
+	// The labels that will be added to the log streams published to Loki. Label
+	// values can reference fields of the log event using templates (e.g.
+	// {{ resource_name }}).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Labels []LokiLabel `json:"labels"`
+
+	// Additional headers that will be added to every request sent to the
+	// endpoint.
+	//
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Headers []HTTPHeader `json:"headers,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoint.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Configures how telemetry data should be batched before sending to the sink.
+	// By default, the sink will batch telemetry data every 5 seconds or when
+	// the batch size reaches 500 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5s", maxSize: 500}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to send
+	// requests to the sink's endpoint. There's no guarantees that the export
+	// policy will retry until success if the endpoint is not available or
+	// configured incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// The compression applied to the objects written to object storage.
+//
+// +kubebuilder:validation:Enum=None;Gzip;Zstd
+type ObjectStorageCompression string
+
+const (
+	// Objects are not compressed.
+	ObjectStorageCompressionNone ObjectStorageCompression = "None"
+	// Objects are compressed using gzip.
+	ObjectStorageCompressionGzip ObjectStorageCompression = "Gzip"
+	// Objects are compressed using zstd.
+	ObjectStorageCompressionZstd ObjectStorageCompression = "Zstd"
+)
+
+// Configures the export policy to archive telemetry in an S3 compatible object
+// storage bucket. Each batch of telemetry is written to a new object as
+// newline delimited JSON. Metrics are converted into JSON events before
+// they're written.
+type ObjectStorageSink struct {
+	// The name of the bucket the objects are written to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// The prefix of the keys of the objects written to the bucket. The prefix
+	// can reference fields of the telemetry event using templates and the time
+	// the object is written using strftime specifiers (e.g. date=%F/). Log
+	// fields are referenced by name (e.g. {{ resource_name }}/) while metric
+	// labels are referenced as tags (e.g. {{ tags.resource_name }}/) since
+	// metrics are converted into events with their labels stored in the tags
+	// field. Defaults to `date=%F/`.
+	//
+	// +kubebuilder:default="date=%F/"
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// The region of the bucket (e.g. us-east-1).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// The URL of an S3 compatible object storage service (e.g.
+	// https://minio.example.com). Defaults to the Amazon S3 endpoint of the
+	// region.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Configures which secret is used to retrieve the access keys used to
+	// write to the bucket. The secret must contain the `access-key-id` and
+	// `secret-access-key` keys.
+	//
+	// +kubebuilder:validation:Required
+	SecretRef LocalSecretReference `json:"secretRef"`
+
+	// The compression applied to the objects. Defaults to Gzip.
+	//
+	// +kubebuilder:default=Gzip
+	Compression ObjectStorageCompression `json:"compression,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoint.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Configures how telemetry data should be batched before it's written to
+	// an object. By default, an object is written every 5 minutes or when the
+	// batch size reaches 5000 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5m", maxSize: 5000}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to write
+	// objects to the bucket. There's no guarantees that the export policy will
+	// retry until success if the bucket is not available or configured
+	// incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// The encoding used for the records published to Kafka.
+//
+// +kubebuilder:validation:Enum=JSON;Protobuf
+type KafkaEncoding string
+
+const (
+	// Each record is encoded as a JSON object.
+	KafkaEncodingJSON KafkaEncoding = "JSON"
+	// Each record is encoded as a protobuf message using vector's native
+	// event schema.
+	KafkaEncodingProtobuf KafkaEncoding = "Protobuf"
+)
+
+// The SASL mechanism used to authenticate with the Kafka brokers.
+//
+// +kubebuilder:validation:Enum=SCRAM-SHA-256;SCRAM-SHA-512
+type KafkaSASLMechanism string
+
+const (
+	// Authenticates using SCRAM with the SHA-256 hash function.
+	KafkaSASLMechanismScramSHA256 KafkaSASLMechanism = "SCRAM-SHA-256"
+	// Authenticates using SCRAM with the SHA-512 hash function.
+	KafkaSASLMechanismScramSHA512 KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+// Configures the export policy to stream telemetry to Apache Kafka. Each
+// metric or log event is published as a separate record.
+type KafkaSink struct {
+	// The addresses of the brokers used to bootstrap the connection to the
+	// Kafka cluster (e.g. kafka-0.example.com:9092).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	Brokers []string `json:"brokers"`
+
+	// The topic records are published to. The topic can reference fields of
+	// the telemetry event using templates. Log fields are referenced by name
+	// (e.g. telemetry-{{ resource_kind }}) while metric labels are referenced
+	// as tags (e.g. telemetry-{{ tags.resource_kind }}).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// Configures how the sink authenticates with the Kafka brokers.
+	SASL *KafkaSASL `json:"sasl,omitempty"`
+
+	// Configures the TLS settings used when connecting to the brokers. TLS is
+	// only enabled when this is configured.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// The encoding used for the records. Defaults to JSON.
+	//
+	// +kubebuilder:default=JSON
+	Encoding KafkaEncoding `json:"encoding,omitempty"`
+}
+
+// Configures SASL/SCRAM authentication with the Kafka brokers.
+type KafkaSASL struct {
+	// The SCRAM mechanism used to authenticate. Defaults to SCRAM-SHA-512.
+	//
+	// +kubebuilder:default=SCRAM-SHA-512
+	Mechanism KafkaSASLMechanism `json:"mechanism,omitempty"`
+
+	// Configures which secret is used to retrieve the credentials used to
+	// authenticate. Secret must be a `kubernetes.io/basic-auth` type.
+	//
+	// +kubebuilder:validation:Required
+	SecretRef LocalSecretReference `json:"secretRef"`
+}
+
+// Configures the export policy to publish logs to a Splunk HTTP Event
+// Collector (HEC). Each log is published as a JSON encoded event.
+type SplunkHECSink struct {
+	// The base URL of the Splunk HEC endpoint that logs will be published to
+	// (e.g. https://http-inputs-example.splunkcloud.com). The event API path
+	// is added automatically.
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Selects the key of a secret that contains the HEC token used to
+	// authenticate with the endpoint.
+	//
+	// +kubebuilder:validation:Required
+	TokenSecretKeyRef LocalSecretKeyReference `json:"tokenSecretKeyRef"`
+
+	// The index logs are published to. The value can reference fields of the
+	// log event using templates (e.g. {{ resource_namespace }}). Defaults to
+	// the default index of the HEC token.
+	Index string `json:"index,omitempty"`
+
+	// The sourcetype of the published events. The value can reference fields
+	// of the log event using templates. Defaults to `httpevent`.
+	SourceType string `json:"sourceType,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoint.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Configures how telemetry data should be batched before sending to the sink.
+	// By default, the sink will batch telemetry data every 5 seconds or when
+	// the batch size reaches 500 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5s", maxSize: 500}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to send
+	// requests to the sink's endpoint. There's no guarantees that the export
+	// policy will retry until success if the endpoint is not available or
+	// configured incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// Configures the export policy to publish logs to Elasticsearch. Logs are
+// indexed using the bulk API unless the sink is configured to publish to a
+// data stream.
+type ElasticsearchSink struct {
+	// The base URLs of the Elasticsearch nodes that logs will be published to
+	// (e.g. https://example.es.us-central1.gcp.cloud.es.io). Requests are
+	// balanced across the endpoints.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	Endpoints []string `json:"endpoints"`
+
+	// The index logs are written to when using the bulk API. The index can
+	// reference fields of the log event using templates and the time of the
+	// log using strftime specifiers. Defaults to `telemetry-%Y.%m.%d`. Can
+	// not be configured with a data stream.
+	Index string `json:"index,omitempty"`
+
+	// Configures the sink to publish logs to a data stream instead of an
+	// index. The data stream is named `<type>-<dataset>-<namespace>`.
+	DataStream *ElasticsearchDataStream `json:"dataStream,omitempty"`
+
+	// Configures how the sink should authenticate with Elasticsearch.
+	Authentication *ElasticsearchAuthentication `json:"authentication,omitempty"`
+
+	// Configures the TLS settings used when connecting to the endpoints.
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Configures how telemetry data should be batched before sending to the sink.
+	// By default, the sink will batch telemetry data every 5 seconds or when
+	// the batch size reaches 500 entries, whichever comes first.
+	//
+	// +kubebuilder:default={timeout: "5s", maxSize: 500}
+	Batch Batch `json:"batch"`
+
+	// Configures the export policies' retry behavior when it fails to send
+	// requests to the sink's endpoint. There's no guarantees that the export
+	// policy will retry until success if the endpoint is not available or
+	// configured incorrectly.
+	//
+	// +kubebuilder:default={maxAttempts: 3, backoffDuration: "5s"}
+	Retry Retry `json:"retry"`
+}
+
+// Configures the data stream logs are published to. Each value can reference
+// fields of the log event using templates.
+type ElasticsearchDataStream struct {
+	// The type of the data stream. Defaults to `logs`.
+	//
+	// +kubebuilder:default=logs
+	Type string `json:"type,omitempty"`
+
+	// The dataset of the data stream. Defaults to `generic`.
+	//
+	// +kubebuilder:default=generic
+	Dataset string `json:"dataset,omitempty"`
+
+	// The namespace of the data stream. Defaults to `default`.
+	//
+	// +kubebuilder:default=default
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Configures how the sink should authenticate with Elasticsearch. These
+// options are mutually exclusive.
+type ElasticsearchAuthentication struct {
+	// Configures the sink to use basic auth to authenticate with
+	// Elasticsearch.
+	BasicAuth *BasicAuthAuthentication `json:"basicAuth,omitempty"`
+
+	// Configures the sink to use an API key to authenticate with
+	// Elasticsearch.
+	APIKey *APIKeyAuthentication `json:"apiKey,omitempty"`
+}
+
+// Configures how the sink should use an API key for authenticating with a
+// telemetry endpoint.
+type APIKeyAuthentication struct {
+	// Selects the key of a secret that contains the base64 encoded API key
+	// to add to the authorization header.
+	//
+	// +kubebuilder:validation:Required
+	SecretKeyRef LocalSecretKeyReference `json:"secretKeyRef"`
+}
+
+// A label that's added to the log streams published to Loki.
+type LokiLabel struct {
+	// The name of the label.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// The value of the label. The value can reference fields of the log event
+	// using templates (e.g. {{ resource_kind }}).
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+}
+
 // Configures the batching behavior the sink will use to batch requests before
 // publishing them to the endpoint.
 type Batch struct {
@@ -238,6 +1370,7 @@ type Retry struct {
 	// +kubebuilder:validation:Maximum=10
 	MaxAttempts int `json:"maxAttempts"`
 	// Backoff duration that should be used to backoff when retrying requests.
+	// Must be a whole number of seconds (e.g. 5s).
 	//
 	// +kubebuilder:validation:Required
 	BackoffDuration metav1.Duration `json:"backoffDuration"`
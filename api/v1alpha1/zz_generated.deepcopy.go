@@ -11,6 +11,38 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuthentication.
+func (in *APIKeyAuthentication) DeepCopy() *APIKeyAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSigV4Authentication) DeepCopyInto(out *AWSSigV4Authentication) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSigV4Authentication.
+func (in *AWSSigV4Authentication) DeepCopy() *AWSSigV4Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSigV4Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authentication) DeepCopyInto(out *Authentication) {
 	*out = *in
@@ -19,6 +51,21 @@ func (in *Authentication) DeepCopyInto(out *Authentication) {
 		*out = new(BasicAuthAuthentication)
 		**out = **in
 	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(BearerTokenAuthentication)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2Authentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSigV4 != nil {
+		in, out := &in.AWSSigV4, &out.AWSSigV4
+		*out = new(AWSSigV4Authentication)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
@@ -63,6 +110,157 @@ func (in *Batch) DeepCopy() *Batch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BearerTokenAuthentication) DeepCopyInto(out *BearerTokenAuthentication) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BearerTokenAuthentication.
+func (in *BearerTokenAuthentication) DeepCopy() *BearerTokenAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(BearerTokenAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(LocalSecretKeyReference)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(LocalConfigMapKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CardinalityLimit) DeepCopyInto(out *CardinalityLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CardinalityLimit.
+func (in *CardinalityLimit) DeepCopy() *CardinalityLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(CardinalityLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetricsSink) DeepCopyInto(out *DatadogMetricsSink) {
+	*out = *in
+	out.APIKeySecretRef = in.APIKeySecretRef
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricsSink.
+func (in *DatadogMetricsSink) DeepCopy() *DatadogMetricsSink {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetricsSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchAuthentication) DeepCopyInto(out *ElasticsearchAuthentication) {
+	*out = *in
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuthAuthentication)
+		**out = **in
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(APIKeyAuthentication)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchAuthentication.
+func (in *ElasticsearchAuthentication) DeepCopy() *ElasticsearchAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchDataStream) DeepCopyInto(out *ElasticsearchDataStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchDataStream.
+func (in *ElasticsearchDataStream) DeepCopy() *ElasticsearchDataStream {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchDataStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSink) DeepCopyInto(out *ElasticsearchSink) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataStream != nil {
+		in, out := &in.DataStream, &out.DataStream
+		*out = new(ElasticsearchDataStream)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ElasticsearchAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSink.
+func (in *ElasticsearchSink) DeepCopy() *ElasticsearchSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExportPolicy) DeepCopyInto(out *ExportPolicy) {
 	*out = *in
@@ -161,6 +359,13 @@ func (in *ExportPolicyStatus) DeepCopyInto(out *ExportPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Sinks != nil {
 		in, out := &in.Sinks, &out.Sinks
 		*out = make([]SinkStatus, len(*in))
@@ -181,156 +386,798 @@ func (in *ExportPolicyStatus) DeepCopy() *ExportPolicyStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalSecretReference) DeepCopyInto(out *LocalSecretReference) {
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
 	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(LocalSecretKeyReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSecretReference.
-func (in *LocalSecretReference) DeepCopy() *LocalSecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalSecretReference)
+	out := new(HTTPHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricSource) DeepCopyInto(out *MetricSource) {
+func (in *KafkaSASL) DeepCopyInto(out *KafkaSASL) {
 	*out = *in
+	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSource.
-func (in *MetricSource) DeepCopy() *MetricSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASL.
+func (in *KafkaSASL) DeepCopy() *KafkaSASL {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricSource)
+	out := new(KafkaSASL)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusRemoteWriteSink) DeepCopyInto(out *PrometheusRemoteWriteSink) {
+func (in *KafkaSink) DeepCopyInto(out *KafkaSink) {
 	*out = *in
-	if in.Authentication != nil {
-		in, out := &in.Authentication, &out.Authentication
-		*out = new(Authentication)
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASL)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Batch = in.Batch
-	out.Retry = in.Retry
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWriteSink.
-func (in *PrometheusRemoteWriteSink) DeepCopy() *PrometheusRemoteWriteSink {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSink.
+func (in *KafkaSink) DeepCopy() *KafkaSink {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusRemoteWriteSink)
+	out := new(KafkaSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Retry) DeepCopyInto(out *Retry) {
+func (in *LabelMatcher) DeepCopyInto(out *LabelMatcher) {
 	*out = *in
-	out.BackoffDuration = in.BackoffDuration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
-func (in *Retry) DeepCopy() *Retry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelMatcher.
+func (in *LabelMatcher) DeepCopy() *LabelMatcher {
 	if in == nil {
 		return nil
 	}
-	out := new(Retry)
+	out := new(LabelMatcher)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SinkStatus) DeepCopyInto(out *SinkStatus) {
+func (in *LocalConfigMapKeyReference) DeepCopyInto(out *LocalConfigMapKeyReference) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfigMapKeyReference.
+func (in *LocalConfigMapKeyReference) DeepCopy() *LocalConfigMapKeyReference {
+	if in == nil {
+		return nil
 	}
+	out := new(LocalConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkStatus.
-func (in *SinkStatus) DeepCopy() *SinkStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSecretKeyReference) DeepCopyInto(out *LocalSecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSecretKeyReference.
+func (in *LocalSecretKeyReference) DeepCopy() *LocalSecretKeyReference {
 	if in == nil {
 		return nil
 	}
-	out := new(SinkStatus)
+	out := new(LocalSecretKeyReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SinkTarget) DeepCopyInto(out *SinkTarget) {
+func (in *LocalSecretReference) DeepCopyInto(out *LocalSecretReference) {
 	*out = *in
-	if in.PrometheusRemoteWrite != nil {
-		in, out := &in.PrometheusRemoteWrite, &out.PrometheusRemoteWrite
-		*out = new(PrometheusRemoteWriteSink)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSecretReference.
+func (in *LocalSecretReference) DeepCopy() *LocalSecretReference {
+	if in == nil {
+		return nil
 	}
+	out := new(LocalSecretReference)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkTarget.
-func (in *SinkTarget) DeepCopy() *SinkTarget {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSource) DeepCopyInto(out *LogSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSource.
+func (in *LogSource) DeepCopy() *LogSource {
 	if in == nil {
 		return nil
 	}
-	out := new(SinkTarget)
+	out := new(LogSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TelemetrySink) DeepCopyInto(out *TelemetrySink) {
+func (in *LokiLabel) DeepCopyInto(out *LokiLabel) {
 	*out = *in
-	if in.Sources != nil {
-		in, out := &in.Sources, &out.Sources
-		*out = make([]string, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiLabel.
+func (in *LokiLabel) DeepCopy() *LokiLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiSink) DeepCopyInto(out *LokiSink) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(Authentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]LokiLabel, len(*in))
 		copy(*out, *in)
 	}
-	if in.Target != nil {
-		in, out := &in.Target, &out.Target
-		*out = new(SinkTarget)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySink.
-func (in *TelemetrySink) DeepCopy() *TelemetrySink {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiSink.
+func (in *LokiSink) DeepCopy() *LokiSink {
 	if in == nil {
 		return nil
 	}
-	out := new(TelemetrySink)
+	out := new(LokiSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TelemetrySource) DeepCopyInto(out *TelemetrySource) {
+func (in *MetricAggregation) DeepCopyInto(out *MetricAggregation) {
 	*out = *in
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(MetricSource)
-		**out = **in
+	out.Window = in.Window
+	if in.By != nil {
+		in, out := &in.By, &out.By
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySource.
-func (in *TelemetrySource) DeepCopy() *TelemetrySource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricAggregation.
+func (in *MetricAggregation) DeepCopy() *MetricAggregation {
 	if in == nil {
 		return nil
 	}
-	out := new(TelemetrySource)
+	out := new(MetricAggregation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSelector) DeepCopyInto(out *MetricSelector) {
+	*out = *in
+	if in.ResourceKinds != nil {
+		in, out := &in.ResourceKinds, &out.ResourceKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNamespaces != nil {
+		in, out := &in.ResourceNamespaces, &out.ResourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricNames != nil {
+		in, out := &in.MetricNames, &out.MetricNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelMatchers != nil {
+		in, out := &in.LabelMatchers, &out.LabelMatchers
+		*out = make([]LabelMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSelector.
+func (in *MetricSelector) DeepCopy() *MetricSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSource) DeepCopyInto(out *MetricSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(MetricSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(MetricAggregation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSource.
+func (in *MetricSource) DeepCopy() *MetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2Authentication) DeepCopyInto(out *OAuth2Authentication) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2Authentication.
+func (in *OAuth2Authentication) DeepCopy() *OAuth2Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageSink) DeepCopyInto(out *ObjectStorageSink) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSink.
+func (in *ObjectStorageSink) DeepCopy() *ObjectStorageSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryHTTPSink) DeepCopyInto(out *OpenTelemetryHTTPSink) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(Authentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryHTTPSink.
+func (in *OpenTelemetryHTTPSink) DeepCopy() *OpenTelemetryHTTPSink {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryHTTPSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetrySink) DeepCopyInto(out *OpenTelemetrySink) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(OpenTelemetryHTTPSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetrySink.
+func (in *OpenTelemetrySink) DeepCopy() *OpenTelemetrySink {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetrySink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWriteSink) DeepCopyInto(out *PrometheusRemoteWriteSink) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(Authentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWriteSink.
+func (in *PrometheusRemoteWriteSink) DeepCopy() *PrometheusRemoteWriteSink {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWriteSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelabelConfig) DeepCopyInto(out *RelabelConfig) {
+	*out = *in
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replacement != nil {
+		in, out := &in.Replacement, &out.Replacement
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelabelConfig.
+func (in *RelabelConfig) DeepCopy() *RelabelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RelabelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceAttributeMatch) DeepCopyInto(out *ResourceAttributeMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceAttributeMatch.
+func (in *ResourceAttributeMatch) DeepCopy() *ResourceAttributeMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceAttributeMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	out.BackoffDuration = in.BackoffDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkBuffer) DeepCopyInto(out *SinkBuffer) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkBuffer.
+func (in *SinkBuffer) DeepCopy() *SinkBuffer {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkBuffer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkStatus) DeepCopyInto(out *SinkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSentTime != nil {
+		in, out := &in.LastSentTime, &out.LastSentTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkStatus.
+func (in *SinkStatus) DeepCopy() *SinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkTarget) DeepCopyInto(out *SinkTarget) {
+	*out = *in
+	if in.PrometheusRemoteWrite != nil {
+		in, out := &in.PrometheusRemoteWrite, &out.PrometheusRemoteWrite
+		*out = new(PrometheusRemoteWriteSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetrySink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatadogMetrics != nil {
+		in, out := &in.DatadogMetrics, &out.DatadogMetrics
+		*out = new(DatadogMetricsSink)
+		**out = **in
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(LokiSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(ObjectStorageSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SplunkHEC != nil {
+		in, out := &in.SplunkHEC, &out.SplunkHEC
+		*out = new(SplunkHECSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(ElasticsearchSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkTarget.
+func (in *SinkTarget) DeepCopy() *SinkTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkTransforms) DeepCopyInto(out *SinkTransforms) {
+	*out = *in
+	if in.IncludeMetrics != nil {
+		in, out := &in.IncludeMetrics, &out.IncludeMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeMetrics != nil {
+		in, out := &in.ExcludeMetrics, &out.ExcludeMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropLabels != nil {
+		in, out := &in.DropLabels, &out.DropLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeepLabels != nil {
+		in, out := &in.KeepLabels, &out.KeepLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddLabels != nil {
+		in, out := &in.AddLabels, &out.AddLabels
+		*out = make([]StaticLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkTransforms.
+func (in *SinkTransforms) DeepCopy() *SinkTransforms {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkTransforms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
+func (in *SourceStatus) DeepCopy() *SourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplunkHECSink) DeepCopyInto(out *SplunkHECSink) {
+	*out = *in
+	out.TokenSecretKeyRef = in.TokenSecretKeyRef
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Batch = in.Batch
+	out.Retry = in.Retry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplunkHECSink.
+func (in *SplunkHECSink) DeepCopy() *SplunkHECSink {
+	if in == nil {
+		return nil
+	}
+	out := new(SplunkHECSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticLabel) DeepCopyInto(out *StaticLabel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticLabel.
+func (in *StaticLabel) DeepCopy() *StaticLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(LocalSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetrySink) DeepCopyInto(out *TelemetrySink) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(SinkTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = new(SinkTransforms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CardinalityLimit != nil {
+		in, out := &in.CardinalityLimit, &out.CardinalityLimit
+		*out = new(CardinalityLimit)
+		**out = **in
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(SinkBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySink.
+func (in *TelemetrySink) DeepCopy() *TelemetrySink {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetrySink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetrySource) DeepCopyInto(out *TelemetrySource) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(LogSource)
+		**out = **in
+	}
+	if in.Traces != nil {
+		in, out := &in.Traces, &out.Traces
+		*out = new(TraceSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySource.
+func (in *TelemetrySource) DeepCopy() *TelemetrySource {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetrySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TraceSource) DeepCopyInto(out *TraceSource) {
+	*out = *in
+	if in.ServiceNames != nil {
+		in, out := &in.ServiceNames, &out.ServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make([]ResourceAttributeMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceSource.
+func (in *TraceSource) DeepCopy() *TraceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TraceSource)
 	in.DeepCopyInto(out)
 	return out
 }
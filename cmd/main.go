@@ -285,9 +285,18 @@ func main() {
 		DownstreamClient:                downstreamCluster.GetClient(),
 		DownstreamVectorConfigNamespace: vectorConfigurationNamespace,
 		MetricsService: controller.MetricsService{
-			Endpoint: os.Getenv("TELEMETRY_SERVICE_METRICS_ENDPOINT"),
-			Username: os.Getenv("TELEMETRY_SERVICE_METRICS_USERNAME"),
-			Password: os.Getenv("TELEMETRY_SERVICE_METRICS_PASSWORD"),
+			Endpoint:      os.Getenv("TELEMETRY_SERVICE_METRICS_ENDPOINT"),
+			Username:      os.Getenv("TELEMETRY_SERVICE_METRICS_USERNAME"),
+			Password:      os.Getenv("TELEMETRY_SERVICE_METRICS_PASSWORD"),
+			QueryEndpoint: os.Getenv("TELEMETRY_SERVICE_METRICS_QUERY_ENDPOINT"),
+		},
+		LogsService: controller.LogsService{
+			Endpoint: os.Getenv("TELEMETRY_SERVICE_LOGS_ENDPOINT"),
+			Username: os.Getenv("TELEMETRY_SERVICE_LOGS_USERNAME"),
+			Password: os.Getenv("TELEMETRY_SERVICE_LOGS_PASSWORD"),
+		},
+		TracesService: controller.TracesService{
+			Enabled: os.Getenv("TELEMETRY_SERVICE_TRACES_ENABLED") == "true",
 		},
 		VectorConfigLabelKey:   vectorConfigLabelKey,
 		VectorConfigLabelValue: vectorConfigLabelValue,
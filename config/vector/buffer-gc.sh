@@ -0,0 +1,46 @@
+#!/bin/sh
+# Removes the disk buffers of export policy sinks that are no longer
+# configured. Vector stores the disk buffer of a sink in a directory named
+# after the sink's component ID, and the directory isn't removed when the sink
+# is removed. Component IDs include the UID of the export policy, so the disk
+# buffers of deleted export policies are never reused and would otherwise fill
+# the shared data volume.
+set -eu
+
+CONFIG_DIR="${CONFIG_DIR:-/etc/vector}"
+BUFFER_DIR="${BUFFER_DIR:-/var/lib/vector/buffer/v2}"
+# Buffers are only removed when they haven't been written to recently so
+# vector has time to stop the sinks that were removed from the configuration.
+MIN_AGE_MINUTES="${MIN_AGE_MINUTES:-15}"
+INTERVAL_SECONDS="${INTERVAL_SECONDS:-300}"
+
+collect() {
+  [ -d "$BUFFER_DIR" ] || return 0
+
+  for buffer in "$BUFFER_DIR"/export-policy:*; do
+    [ -d "$buffer" ] || continue
+    id="$(basename "$buffer")"
+
+    # The buffer's sink is still configured.
+    if grep -rqF -- "\"$id\"" "$CONFIG_DIR"; then
+      continue
+    fi
+
+    if [ -n "$(find "$buffer" -maxdepth 0 -mmin "-$MIN_AGE_MINUTES")" ]; then
+      continue
+    fi
+
+    echo "removing the disk buffer of sink $id that is no longer configured"
+    rm -rf -- "$buffer"
+  done
+}
+
+if [ "${1:-}" = "--once" ]; then
+  collect
+  exit 0
+fi
+
+while true; do
+  collect
+  sleep "$INTERVAL_SECONDS"
+done
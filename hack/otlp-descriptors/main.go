@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Command otlp-descriptors writes the protobuf descriptor set that vector uses
+// to encode the OTLP export requests published by OpenTelemetry sinks.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	collectormetricsv1 "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func main() {
+	output := flag.String("output", "config/vector/otlp.desc", "The file the descriptor set is written to.")
+	flag.Parse()
+
+	descriptorSet := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+
+	// Files are added after their imports so vector can resolve every type
+	// while loading the descriptor set.
+	var addFile func(file protoreflect.FileDescriptor)
+	addFile = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			addFile(imports.Get(i).FileDescriptor)
+		}
+		descriptorSet.File = append(descriptorSet.File, protodesc.ToFileDescriptorProto(file))
+	}
+
+	addFile(collectormetricsv1.File_opentelemetry_proto_collector_metrics_v1_metrics_service_proto)
+	addFile(collectortracev1.File_opentelemetry_proto_collector_trace_v1_trace_service_proto)
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(descriptorSet)
+	if err != nil {
+		log.Fatalf("failed to marshal descriptor set: %v", err)
+	}
+
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		log.Fatalf("failed to write descriptor set: %v", err)
+	}
+}
@@ -5,8 +5,12 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -20,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/finalizer"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
@@ -57,12 +62,22 @@ type ExportPolicyReconciler struct {
 	// system.
 	MetricsService MetricsService
 
+	// The logs service that can be used to query logs from the telemetry
+	// system.
+	LogsService LogsService
+
+	// The platform trace receiver that trace sources select spans from.
+	TracesService TracesService
+
 	// The vector config label key that will be added to the vector config secret.
 	VectorConfigLabelKey   string
 	VectorConfigLabelValue string
 
 	// Finalizers manager
 	finalizers finalizer.Finalizers
+
+	// The export policies that are reconciled by the controller.
+	policies exportPolicySet
 }
 
 // MetricsService is a struct that contains the information needed to configure
@@ -75,6 +90,31 @@ type MetricsService struct {
 	Username string
 	// The password for the metrics service.
 	Password string
+	// The base URL of the Prometheus compatible query API of the metrics
+	// service. This is used to query the internal metrics reported by vector
+	// to determine the status of export policies.
+	QueryEndpoint string
+}
+
+// LogsService is a struct that contains the information needed to configure a
+// logs service.
+type LogsService struct {
+	// The URL of the LogsQL query endpoint of the logs service that can be used
+	// to query logs from the telemetry system.
+	Endpoint string
+	// The username for the logs service.
+	Username string
+	// The password for the logs service.
+	Password string
+}
+
+// TracesService is a struct that contains the information needed to configure
+// trace sources.
+type TracesService struct {
+	// Whether the vector traces component is deployed, which receives the
+	// platform's spans from the platform's trace collector. Trace sources are
+	// not accepted when the receiver isn't enabled.
+	Enabled bool
 }
 
 // vectorSecretFinalizer handles deletion of the downstream Vector config Secret.
@@ -123,6 +163,7 @@ func (f *vectorSecretFinalizer) Finalize(ctx context.Context, obj client.Object)
 // +kubebuilder:rbac:groups=telemetry.miloapis.com,resources=exportpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=telemetry.miloapis.com,resources=exportpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 
 // Reconcile an Export Policy and ensure the necessary resources exist to export
 // the telemetry sources that are configured. This will create a vector config
@@ -139,6 +180,8 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 
 	logger.Info("reconciling export policy")
 
+	policyKey := req.ClusterName + "/" + req.NamespacedName.String()
+
 	cluster, err := r.mgr.GetCluster(ctx, req.ClusterName)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get cluster: %w", err)
@@ -150,6 +193,7 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 	if err := upstreamClient.Get(ctx, req.NamespacedName, exportPolicy); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info("export policy not found, assuming deleted")
+			r.policies.remove(policyKey)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get export policy: %w", err)
@@ -172,8 +216,10 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 	// Don't process the export policy if it is marked for deletion.
 	if !exportPolicy.DeletionTimestamp.IsZero() {
 		logger.Info("export policy is marked for deletion, stopping reconciliation")
+		r.policies.remove(policyKey)
 		return ctrl.Result{}, nil
 	}
+	r.policies.add(policyKey)
 
 	// Validate that the export policy configuration is valid and update the
 	// status of the export policy to reflect the status of the sinks.
@@ -188,7 +234,8 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 
 	// Create the vector configuration for the export policy. This will skip over
 	// any source or sink configurations that are not valid.
-	vectorConfig := r.createVectorConfiguration(ctx, strings.ReplaceAll(req.ClusterName, "/", ""), upstreamClient, exportPolicy)
+	projectName := strings.ReplaceAll(req.ClusterName, "/", "")
+	vectorConfig := r.createVectorConfiguration(ctx, projectName, upstreamClient, exportPolicy)
 	vectorConfigJSON, err := json.MarshalIndent(vectorConfig, "", "  ")
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to marshal vector config: %w", err)
@@ -200,26 +247,30 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("export-policy-vector-config-%s", exportPolicy.GetUID()),
 			Namespace: r.DownstreamVectorConfigNamespace,
-			Labels: map[string]string{
-				r.VectorConfigLabelKey:     r.VectorConfigLabelValue,
-				exportPolicyNameLabel:      exportPolicy.Name,
-				exportPolicyNamespaceLabel: exportPolicy.Namespace,
-			},
-		},
-		Data: map[string][]byte{
-			fmt.Sprintf("%s.json", exportPolicy.UID): vectorConfigJSON,
 		},
 	}
 
 	logger.Info("creating or updating downstream secret")
+	configKey := fmt.Sprintf("%s.json", exportPolicy.UID)
 	operationResult, err := controllerutil.CreateOrUpdate(ctx, r.DownstreamClient, configSecret, func() error {
 		configSecret.Labels = map[string]string{
 			r.VectorConfigLabelKey:     r.VectorConfigLabelValue,
 			exportPolicyNameLabel:      exportPolicy.Name,
 			exportPolicyNamespaceLabel: exportPolicy.Namespace,
 		}
+
+		// Record when the vector configuration changed so the Programmed
+		// condition can account for the time it takes vector to load it.
+		if configSecret.Annotations == nil {
+			configSecret.Annotations = map[string]string{}
+		}
+		if vectorConfigChanged(configSecret.Data[configKey], vectorConfigJSON) {
+			configSecret.Annotations[exportPolicyConfigUpdatedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		}
+		configSecret.Annotations[exportPolicyGenerationAnnotation] = strconv.FormatInt(exportPolicy.Generation, 10)
+
 		configSecret.Data = map[string][]byte{
-			fmt.Sprintf("%s.json", exportPolicy.UID): vectorConfigJSON,
+			configKey: vectorConfigJSON,
 		}
 		return nil
 	})
@@ -231,56 +282,103 @@ func (r *ExportPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.
 		logger.Info("downstream secret operation result", "operation", operationResult)
 	}
 
+	// Update the status of the export policy with the status that's reported
+	// by vector's internal metrics.
+	if r.reconcileVectorStatus(ctx, projectName, exportPolicy, configSecret, vectorConfig) {
+		logger.Info("export policy vector status changed, updating status")
+		if err := upstreamClient.Status().Update(ctx, exportPolicy); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update export policy status: %w", err)
+		}
+	}
+
 	logger.Info("export policy reconciliation complete")
-	return ctrl.Result{}, nil
+
+	// The status reported from vector's internal metrics can only be observed
+	// by periodically reconciling the export policy.
+	requeueAfter := time.Duration(0)
+	if r.MetricsService.QueryEnabled() {
+		requeueAfter = vectorStatusRefreshInterval(r.policies.len())
+	}
+
+	// Access tokens retrieved with OAuth2 are short-lived, so the policy needs
+	// to be reconciled periodically to refresh the tokens in the vector
+	// configuration before they expire.
+	if usesOAuth2Authentication(exportPolicy) && (requeueAfter == 0 || oauth2TokenRefreshInterval < requeueAfter) {
+		requeueAfter = oauth2TokenRefreshInterval
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // reconcileExportPolicyStatus validates the export policy configuration and
 // updates the status of the export policy to reflect the status of the sinks.
 func reconcileExportPolicyStatus(ctx context.Context, client client.Client, exportPolicy *v1alpha1.ExportPolicy) bool {
 	statusChanged := false
+	sourceStatuses := []v1alpha1.SourceStatus{}
+	// Validate each of the sources in the export policy can be translated into
+	// a vector configuration.
+	for _, source := range exportPolicy.Spec.Sources {
+		status := getSourceStatus(exportPolicy, source.Name)
+
+		condition := metav1.Condition{
+			Type:   "Accepted",
+			Status: metav1.ConditionTrue,
+			Reason: "SourceConfigured",
+		}
+		if err := validateSourceConfiguration(source); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = err.reason
+			condition.Message = err.Error()
+		}
+
+		if apimeta.SetStatusCondition(&status.Conditions, condition) {
+			statusChanged = true
+		}
+
+		// Report sources that aren't used by any sinks since they won't export
+		// any telemetry data.
+		referencedCondition := metav1.Condition{
+			Type:    "Referenced",
+			Status:  metav1.ConditionTrue,
+			Reason:  "ReferencedBySink",
+			Message: "The source is used by at least one sink.",
+		}
+		if !slices.ContainsFunc(exportPolicy.Spec.Sinks, func(sink v1alpha1.TelemetrySink) bool { return slices.Contains(sink.Sources, source.Name) }) {
+			referencedCondition.Status = metav1.ConditionFalse
+			referencedCondition.Reason = "NotReferenced"
+			referencedCondition.Message = "The source is not used by any sinks and will not export telemetry data."
+		}
+
+		if apimeta.SetStatusCondition(&status.Conditions, referencedCondition) {
+			statusChanged = true
+		}
+
+		sourceStatuses = append(sourceStatuses, *status)
+	}
+
+	exportPolicy.Status.Sources = sourceStatuses
+
 	sinkStatuses := []v1alpha1.SinkStatus{}
 	// Validate each of the sinks in the export policy have a valid configuration
 	// and the secrets exist if necessary.
 	for _, sink := range exportPolicy.Spec.Sinks {
 		status := getSinkStatus(exportPolicy, sink.Name)
 
-		if sink.Target.PrometheusRemoteWrite != nil {
-			// Assume the sink is accepted and expect to be set to false if any
-			// validation fails.
-			accepted := true
-
-			// Validate that any authentication for the sink is valid
-			if sink.Target.PrometheusRemoteWrite.Authentication != nil {
-				if sink.Target.PrometheusRemoteWrite.Authentication.BasicAuth != nil {
-					_, err := retrieveBasicAuthSecret(ctx, client, sink.Target.PrometheusRemoteWrite.Authentication.BasicAuth.SecretRef, exportPolicy)
-					if err != nil {
-						accepted = false
-						updated := apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
-							Type:    "Accepted",
-							Status:  metav1.ConditionFalse,
-							Reason:  "InvalidAuthentication",
-							Message: err.Error(),
-						})
-
-						if updated {
-							statusChanged = true
-						}
-					}
-				}
-			}
-
-			if accepted {
-				updated := apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
-					Type:   "Accepted",
-					Status: metav1.ConditionTrue,
-					Reason: "SinkConfigured",
-				})
+		// Assume the sink is accepted and expect the condition to be set to false
+		// if any validation fails.
+		condition := metav1.Condition{
+			Type:   "Accepted",
+			Status: metav1.ConditionTrue,
+			Reason: "SinkConfigured",
+		}
+		if err := validateSinkConfiguration(ctx, client, sink, exportPolicy); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = err.reason
+			condition.Message = err.Error()
+		}
 
-				if updated {
-					statusChanged = true
-				}
-			}
+		if apimeta.SetStatusCondition(&status.Conditions, condition) {
+			statusChanged = true
 		}
 
 		sinkStatuses = append(sinkStatuses, *status)
@@ -289,8 +387,80 @@ func reconcileExportPolicyStatus(ctx context.Context, client client.Client, expo
 	exportPolicy.Status.Sinks = sinkStatuses
 
 	// Update the overall status conditions of the export policy based on the
-	// status of its sinks.
-	return updateExportPolicyConditions(exportPolicy, sinkStatuses) || statusChanged
+	// status of its sources and sinks.
+	return updateExportPolicyConditions(exportPolicy, sourceStatuses, sinkStatuses) || statusChanged
+}
+
+// validateSourceConfiguration confirms the source's configuration can be
+// translated into a vector configuration.
+func validateSourceConfiguration(source v1alpha1.TelemetrySource) *sourceConfigurationError {
+	err := checkSourceConfiguration(source)
+	if err == nil {
+		return nil
+	}
+
+	var configErr *sourceConfigurationError
+	if goerrors.As(err, &configErr) {
+		return configErr
+	}
+
+	return &sourceConfigurationError{reason: "InvalidConfiguration", err: err}
+}
+
+// validateSinkConfiguration confirms the sink's configuration can be
+// translated into a vector configuration and that any secrets it references
+// exist and are valid.
+func validateSinkConfiguration(ctx context.Context, client client.Client, sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) *sinkConfigurationError {
+	var unknownSources []string
+	for _, source := range sink.Sources {
+		if !slices.ContainsFunc(exportPolicy.Spec.Sources, func(s v1alpha1.TelemetrySource) bool { return s.Name == source }) {
+			unknownSources = append(unknownSources, source)
+		}
+	}
+	if len(unknownSources) > 0 {
+		return &sinkConfigurationError{
+			reason: "UnknownSource",
+			err:    fmt.Errorf("sink references sources that are not defined in the export policy: %s", strings.Join(unknownSources, ", ")),
+		}
+	}
+
+	err := checkSinkSourceSignals(sink, exportPolicy)
+	if err == nil {
+		_, err = getSinkTransforms(sink, exportPolicy)
+	}
+	if err == nil {
+		_, err = getSinkTargetVectorConfig(ctx, client, sink, exportPolicy)
+	}
+	if err == nil && sink.Buffer != nil {
+		_, err = getBufferVectorConfig(*sink.Buffer)
+	}
+	if err == nil {
+		err = checkDiskBufferBudget(sink, exportPolicy)
+	}
+	if err == nil {
+		return nil
+	}
+
+	var configErr *sinkConfigurationError
+	if goerrors.As(err, &configErr) {
+		return configErr
+	}
+
+	return &sinkConfigurationError{reason: "InvalidConfiguration", err: err}
+}
+
+// getSourceStatus retrieves the existing source status from the export policy
+// if it exists, otherwise returns a new source status with the given name
+func getSourceStatus(exportPolicy *v1alpha1.ExportPolicy, sourceName string) *v1alpha1.SourceStatus {
+	for _, existingStatus := range exportPolicy.Status.Sources {
+		if existingStatus.Name == sourceName {
+			return existingStatus.DeepCopy()
+		}
+	}
+
+	return &v1alpha1.SourceStatus{
+		Name: sourceName,
+	}
 }
 
 // getSinkStatus retrieves the existing sink status from the export policy if it
@@ -308,35 +478,47 @@ func getSinkStatus(exportPolicy *v1alpha1.ExportPolicy, sinkName string) *v1alph
 }
 
 // updateExportPolicyStatus updates the overall status conditions of the
-// export policy based on the status of its sinks. Returns true if conditions
-// were changed.
-func updateExportPolicyConditions(exportPolicy *v1alpha1.ExportPolicy, sinkStatuses []v1alpha1.SinkStatus) bool {
-	var acceptedCount int
+// export policy based on the status of its sources and sinks. Returns true if
+// conditions were changed.
+func updateExportPolicyConditions(exportPolicy *v1alpha1.ExportPolicy, sourceStatuses []v1alpha1.SourceStatus, sinkStatuses []v1alpha1.SinkStatus) bool {
+	var acceptedSources int
+	for _, sourceStatus := range sourceStatuses {
+		if apimeta.IsStatusConditionTrue(sourceStatus.Conditions, "Accepted") {
+			acceptedSources++
+		}
+	}
+
+	var acceptedSinks int
 	for _, sinkStatus := range sinkStatuses {
-		for _, condition := range sinkStatus.Conditions {
-			if condition.Type == "Accepted" {
-				if condition.Status == metav1.ConditionTrue {
-					acceptedCount++
-				}
-			}
+		if apimeta.IsStatusConditionTrue(sinkStatus.Conditions, "Accepted") {
+			acceptedSinks++
 		}
 	}
 
 	var condition metav1.Condition
-	if acceptedCount == len(sinkStatuses) {
+	switch {
+	case acceptedSources != len(sourceStatuses):
 		condition = metav1.Condition{
 			Type:               "Ready",
-			Status:             metav1.ConditionTrue,
-			Reason:             "SinksAccepted",
-			Message:            "All sinks are configured.",
+			Status:             metav1.ConditionFalse,
+			Reason:             "SourcesNotAccepted",
+			Message:            fmt.Sprintf("%d/%d sources are accepted. Check the status of the sources for more details.", acceptedSources, len(sourceStatuses)),
 			ObservedGeneration: exportPolicy.Generation,
 		}
-	} else {
+	case acceptedSinks != len(sinkStatuses):
 		condition = metav1.Condition{
 			Type:               "Ready",
 			Status:             metav1.ConditionFalse,
 			Reason:             "SinksNotAccepted",
-			Message:            fmt.Sprintf("%d/%d sinks are accepted. Check the status of the sinks for more details.", acceptedCount, len(sinkStatuses)),
+			Message:            fmt.Sprintf("%d/%d sinks are accepted. Check the status of the sinks for more details.", acceptedSinks, len(sinkStatuses)),
+			ObservedGeneration: exportPolicy.Generation,
+		}
+	default:
+		condition = metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionTrue,
+			Reason:             "SinksAccepted",
+			Message:            "All sources and sinks are accepted.",
 			ObservedGeneration: exportPolicy.Generation,
 		}
 	}
@@ -363,62 +545,222 @@ func (r *ExportPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	}
 
 	return mcbuilder.ControllerManagedBy(mgr).
-		For(&v1alpha1.ExportPolicy{}, mcbuilder.WithEngageWithLocalCluster(false), mcbuilder.WithEngageWithProviderClusters(true)).
-		Watches(&corev1.Secret{}, func(clusterName string, cluster cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
-			return mchandler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
-				logger := log.FromContext(ctx)
-				secret, ok := obj.(*corev1.Secret)
-				if !ok {
-					logger.Error(fmt.Errorf("object %T is not a Secret", obj), "unexpected type")
-					return nil
-				}
+		// Status updates don't change the generation of the export policy, so
+		// they don't trigger another reconcile. The status is refreshed by
+		// requeueing the export policy instead.
+		For(&v1alpha1.ExportPolicy{},
+			mcbuilder.WithEngageWithLocalCluster(false),
+			mcbuilder.WithEngageWithProviderClusters(true),
+			mcbuilder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(&corev1.Secret{}, enqueueReferencingExportPolicies("secret", func(policy *v1alpha1.ExportPolicy, obj client.Object) bool {
+			secret, ok := obj.(*corev1.Secret)
+			return ok && referencesSecret(policy, secret)
+		})).
+		Watches(&corev1.ConfigMap{}, enqueueReferencingExportPolicies("configmap", func(policy *v1alpha1.ExportPolicy, obj client.Object) bool {
+			configMap, ok := obj.(*corev1.ConfigMap)
+			return ok && referencesConfigMap(policy, configMap)
+		})).
+		Named("exportpolicy").
+		Complete(r)
+}
 
-				// List all ExportPolicies in the same namespace as the secret.
-				// Note: LocalSecretReference implies the secret is in the same namespace.
-				policyList := &v1alpha1.ExportPolicyList{}
-				if err := cluster.GetClient().List(ctx, policyList, client.InNamespace(secret.GetNamespace())); err != nil {
-					logger.Error(err, "failed to list ExportPolicies", "namespace", secret.GetNamespace())
-					return nil
-				}
+// enqueueReferencingExportPolicies returns an event handler that will enqueue
+// all export policies in the same namespace as the changed object that
+// reference the object.
+func enqueueReferencingExportPolicies(kind string, references func(*v1alpha1.ExportPolicy, client.Object) bool) func(string, cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return func(clusterName string, cluster cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+		return mchandler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+			logger := log.FromContext(ctx)
+
+			// List all ExportPolicies in the same namespace as the object.
+			// Note: Local references imply the object is in the same namespace.
+			policyList := &v1alpha1.ExportPolicyList{}
+			if err := cluster.GetClient().List(ctx, policyList, client.InNamespace(obj.GetNamespace())); err != nil {
+				logger.Error(err, "failed to list ExportPolicies", "namespace", obj.GetNamespace())
+				return nil
+			}
 
-				var requests []mcreconcile.Request
-				for _, policy := range policyList.Items {
-					if referencesSecret(&policy, secret) {
-						if requests == nil { // Initialize slice only if needed
-							requests = make([]mcreconcile.Request, 0, 1) // Start with capacity 1
-						}
-						requests = append(requests, mcreconcile.Request{
-							Request: reconcile.Request{
-								NamespacedName: types.NamespacedName{
-									Name:      policy.Name,
-									Namespace: policy.Namespace,
-								},
-							},
-						})
-						// Log the enqueueing for clarity
-						logger.V(1).Info("enqueuing ExportPolicy due to secret change", "exportpolicy", client.ObjectKeyFromObject(&policy), "secret", client.ObjectKeyFromObject(secret))
+			var requests []mcreconcile.Request
+			for _, policy := range policyList.Items {
+				if references(&policy, obj) {
+					if requests == nil { // Initialize slice only if needed
+						requests = make([]mcreconcile.Request, 0, 1) // Start with capacity 1
 					}
+					requests = append(requests, mcreconcile.Request{
+						Request: reconcile.Request{
+							NamespacedName: types.NamespacedName{
+								Name:      policy.Name,
+								Namespace: policy.Namespace,
+							},
+						},
+					})
+					// Log the enqueueing for clarity
+					logger.V(1).Info(fmt.Sprintf("enqueuing ExportPolicy due to %s change", kind), "exportpolicy", client.ObjectKeyFromObject(&policy), kind, client.ObjectKeyFromObject(obj))
 				}
+			}
 
-				return requests
-			})(clusterName, cluster)
-		}).
-		Named("exportpolicy").
-		Complete(r)
+			return requests
+		})(clusterName, cluster)
+	}
 }
 
 // referencesSecret checks if the given ExportPolicy references the provided Secret.
 func referencesSecret(policy *v1alpha1.ExportPolicy, secret *corev1.Secret) bool {
 	for _, sink := range policy.Spec.Sinks {
-		if sink.Target != nil &&
-			sink.Target.PrometheusRemoteWrite != nil &&
-			sink.Target.PrometheusRemoteWrite.Authentication != nil &&
-			sink.Target.PrometheusRemoteWrite.Authentication.BasicAuth != nil &&
-			sink.Target.PrometheusRemoteWrite.Authentication.BasicAuth.SecretRef.Name == secret.Name {
-			// Found a reference in the same namespace
+		// Secret references are always in the same namespace as the policy.
+		if sink.Target != nil && slices.Contains(sinkTargetSecretNames(*sink.Target), secret.Name) {
 			return true
 		}
-		// Add checks for other potential secret references here if needed in the future
 	}
 	return false
 }
+
+// referencesConfigMap checks if the given ExportPolicy references the provided
+// ConfigMap.
+func referencesConfigMap(policy *v1alpha1.ExportPolicy, configMap *corev1.ConfigMap) bool {
+	for _, sink := range policy.Spec.Sinks {
+		if sink.Target != nil && slices.Contains(sinkTargetConfigMapNames(*sink.Target), configMap.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sinkTargetSecretNames returns the names of all secrets referenced by the
+// target of a sink.
+func sinkTargetSecretNames(target v1alpha1.SinkTarget) []string {
+	var names []string
+	if target.PrometheusRemoteWrite != nil {
+		names = append(names, authenticationSecretNames(target.PrometheusRemoteWrite.Authentication)...)
+		names = append(names, headerSecretNames(target.PrometheusRemoteWrite.Headers)...)
+		names = append(names, tlsSecretNames(target.PrometheusRemoteWrite.TLS)...)
+	}
+	if target.OpenTelemetry != nil && target.OpenTelemetry.HTTP != nil {
+		names = append(names, authenticationSecretNames(target.OpenTelemetry.HTTP.Authentication)...)
+		names = append(names, headerSecretNames(target.OpenTelemetry.HTTP.Headers)...)
+		names = append(names, tlsSecretNames(target.OpenTelemetry.HTTP.TLS)...)
+	}
+	if target.DatadogMetrics != nil {
+		names = append(names, target.DatadogMetrics.APIKeySecretRef.Name)
+	}
+	if target.Loki != nil {
+		names = append(names, authenticationSecretNames(target.Loki.Authentication)...)
+		names = append(names, headerSecretNames(target.Loki.Headers)...)
+		names = append(names, tlsSecretNames(target.Loki.TLS)...)
+	}
+	if target.ObjectStorage != nil {
+		names = append(names, target.ObjectStorage.SecretRef.Name)
+		names = append(names, tlsSecretNames(target.ObjectStorage.TLS)...)
+	}
+	if target.Kafka != nil {
+		if target.Kafka.SASL != nil {
+			names = append(names, target.Kafka.SASL.SecretRef.Name)
+		}
+		names = append(names, tlsSecretNames(target.Kafka.TLS)...)
+	}
+	if target.SplunkHEC != nil {
+		names = append(names, target.SplunkHEC.TokenSecretKeyRef.Name)
+		names = append(names, tlsSecretNames(target.SplunkHEC.TLS)...)
+	}
+	if target.Elasticsearch != nil {
+		if auth := target.Elasticsearch.Authentication; auth != nil {
+			if auth.BasicAuth != nil {
+				names = append(names, auth.BasicAuth.SecretRef.Name)
+			}
+			if auth.APIKey != nil {
+				names = append(names, auth.APIKey.SecretKeyRef.Name)
+			}
+		}
+		names = append(names, tlsSecretNames(target.Elasticsearch.TLS)...)
+	}
+	return names
+}
+
+// headerSecretNames returns the names of all secrets referenced by the HTTP
+// headers of a sink.
+func headerSecretNames(headers []v1alpha1.HTTPHeader) []string {
+	var names []string
+	for _, header := range headers {
+		if header.SecretKeyRef != nil {
+			names = append(names, header.SecretKeyRef.Name)
+		}
+	}
+	return names
+}
+
+// tlsSecretNames returns the names of all secrets referenced by the TLS
+// options of a sink.
+func tlsSecretNames(tls *v1alpha1.TLSConfig) []string {
+	var names []string
+	if tls == nil {
+		return names
+	}
+	if tls.CABundle != nil && tls.CABundle.SecretKeyRef != nil {
+		names = append(names, tls.CABundle.SecretKeyRef.Name)
+	}
+	if tls.ClientCertificate != nil {
+		names = append(names, tls.ClientCertificate.Name)
+	}
+	return names
+}
+
+// authenticationSecretNames returns the names of all secrets referenced by the
+// authentication options of a sink.
+func authenticationSecretNames(auth *v1alpha1.Authentication) []string {
+	var names []string
+	if auth == nil {
+		return names
+	}
+	if auth.BasicAuth != nil {
+		names = append(names, auth.BasicAuth.SecretRef.Name)
+	}
+	if auth.BearerToken != nil {
+		names = append(names, auth.BearerToken.SecretKeyRef.Name)
+	}
+	if auth.OAuth2 != nil {
+		names = append(names, auth.OAuth2.ClientSecretRef.Name)
+	}
+	if auth.AWSSigV4 != nil {
+		names = append(names, auth.AWSSigV4.SecretRef.Name)
+	}
+	return names
+}
+
+// sinkTargetConfigMapNames returns the names of all config maps referenced by
+// the target of a sink.
+func sinkTargetConfigMapNames(target v1alpha1.SinkTarget) []string {
+	var names []string
+	if target.PrometheusRemoteWrite != nil {
+		names = append(names, tlsConfigMapNames(target.PrometheusRemoteWrite.TLS)...)
+	}
+	if target.OpenTelemetry != nil && target.OpenTelemetry.HTTP != nil {
+		names = append(names, tlsConfigMapNames(target.OpenTelemetry.HTTP.TLS)...)
+	}
+	if target.Loki != nil {
+		names = append(names, tlsConfigMapNames(target.Loki.TLS)...)
+	}
+	if target.ObjectStorage != nil {
+		names = append(names, tlsConfigMapNames(target.ObjectStorage.TLS)...)
+	}
+	if target.Kafka != nil {
+		names = append(names, tlsConfigMapNames(target.Kafka.TLS)...)
+	}
+	if target.SplunkHEC != nil {
+		names = append(names, tlsConfigMapNames(target.SplunkHEC.TLS)...)
+	}
+	if target.Elasticsearch != nil {
+		names = append(names, tlsConfigMapNames(target.Elasticsearch.TLS)...)
+	}
+	return names
+}
+
+// tlsConfigMapNames returns the names of all config maps referenced by the TLS
+// options of a sink.
+func tlsConfigMapNames(tls *v1alpha1.TLSConfig) []string {
+	var names []string
+	if tls != nil && tls.CABundle != nil && tls.CABundle.ConfigMapKeyRef != nil {
+		names = append(names, tls.CABundle.ConfigMapKeyRef.Name)
+	}
+	return names
+}
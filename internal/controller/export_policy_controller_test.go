@@ -4,11 +4,15 @@ package controller
 
 import (
 	"context"
+	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/finalizer"
@@ -120,3 +124,106 @@ var _ = Describe("ExportPolicy Controller", func() {
 		})
 	})
 })
+
+func TestReferencesSecret(t *testing.T) {
+	tests := []struct {
+		name         string
+		exportPolicy *telemetryv1alpha1.ExportPolicy
+		secretName   string
+		referenced   bool
+	}{
+		{
+			name: "secret referenced by sink headers",
+			exportPolicy: newExportPolicy(func(ep *telemetryv1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Headers = []telemetryv1alpha1.HTTPHeader{
+					{
+						Name: "X-Api-Key",
+						SecretKeyRef: &telemetryv1alpha1.LocalSecretKeyReference{
+							Name: "vendor",
+							Key:  "api-key",
+						},
+					},
+				}
+			}),
+			secretName: "vendor",
+			referenced: true,
+		},
+		{
+			name: "secret referenced by kafka credentials",
+			exportPolicy: newExportPolicy(func(ep *telemetryv1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &telemetryv1alpha1.SinkTarget{
+					Kafka: &telemetryv1alpha1.KafkaSink{
+						Brokers: []string{"kafka.example.com:9093"},
+						Topic:   "telemetry",
+						SASL: &telemetryv1alpha1.KafkaSASL{
+							SecretRef: telemetryv1alpha1.LocalSecretReference{Name: "kafka-credentials"},
+						},
+					},
+				}
+			}),
+			secretName: "kafka-credentials",
+			referenced: true,
+		},
+		{
+			name:         "secret that isn't referenced",
+			exportPolicy: newExportPolicy(),
+			secretName:   "other",
+			referenced:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tt.secretName}}
+			assert.Equal(t, tt.referenced, referencesSecret(tt.exportPolicy, secret))
+		})
+	}
+}
+
+func TestUpdateExportPolicyConditions(t *testing.T) {
+	accepted := []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue}}
+	notAccepted := []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionFalse}}
+
+	tests := []struct {
+		name           string
+		sources        []telemetryv1alpha1.SourceStatus
+		sinks          []telemetryv1alpha1.SinkStatus
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "all sources and sinks accepted",
+			sources:        []telemetryv1alpha1.SourceStatus{{Name: "source", Conditions: accepted}},
+			sinks:          []telemetryv1alpha1.SinkStatus{{Name: "sink", Conditions: accepted}},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "SinksAccepted",
+		},
+		{
+			name:           "source not accepted",
+			sources:        []telemetryv1alpha1.SourceStatus{{Name: "source", Conditions: notAccepted}},
+			sinks:          []telemetryv1alpha1.SinkStatus{{Name: "sink", Conditions: accepted}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "SourcesNotAccepted",
+		},
+		{
+			name:           "sink not accepted",
+			sources:        []telemetryv1alpha1.SourceStatus{{Name: "source", Conditions: accepted}},
+			sinks:          []telemetryv1alpha1.SinkStatus{{Name: "sink", Conditions: notAccepted}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "SinksNotAccepted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportPolicy := newExportPolicy()
+			assert.True(t, updateExportPolicyConditions(exportPolicy, tt.sources, tt.sinks))
+
+			condition := apimeta.FindStatusCondition(exportPolicy.Status.Conditions, "Ready")
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tt.expectedStatus, condition.Status)
+				assert.Equal(t, tt.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
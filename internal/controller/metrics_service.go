@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// metricsQueryTimeout is the maximum amount of time to wait for the metrics
+// service to respond to a query.
+const metricsQueryTimeout = 10 * time.Second
+
+// QueryEnabled returns whether the metrics service can be used to query the
+// internal metrics reported by vector.
+func (s MetricsService) QueryEnabled() bool {
+	return s.QueryEndpoint != ""
+}
+
+// Query runs an instant query against the Prometheus compatible query API of
+// the metrics service and returns the resulting samples.
+func (s MetricsService) Query(ctx context.Context, query string) (model.Vector, error) {
+	client, err := api.NewClient(api.Config{
+		Address: s.QueryEndpoint,
+		Client: &http.Client{
+			Timeout: metricsQueryTimeout,
+			Transport: &basicAuthRoundTripper{
+				username: s.Username,
+				password: s.Password,
+				next:     api.DefaultRoundTripper,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics service client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, metricsQueryTimeout)
+	defer cancel()
+
+	result, _, err := promv1.NewAPI(client).Query(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metrics service: %w", err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type '%s' returned by metrics service", result.Type())
+	}
+
+	return vector, nil
+}
+
+// basicAuthRoundTripper adds basic authentication credentials to requests
+// when a username is configured.
+type basicAuthRoundTripper struct {
+	username string
+	password string
+	next     http.RoundTripper
+}
+
+func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.username == "" {
+		return rt.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.username, rt.password)
+	return rt.next.RoundTrip(req)
+}
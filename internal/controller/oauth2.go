@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+	"go.datum.net/telemetry-services-operator/internal/validation"
+)
+
+const (
+	// oauth2TokenRefreshWindow is how long before an access token expires that
+	// a new access token will be requested. Access tokens that are valid for
+	// less than twice the window are refreshed after half their lifetime.
+	oauth2TokenRefreshWindow = 5 * time.Minute
+
+	// oauth2TokenRefreshInterval is how often export policies that use OAuth2
+	// authentication are reconciled to make sure the access tokens in the
+	// vector configuration are refreshed before they expire.
+	oauth2TokenRefreshInterval = time.Minute
+
+	// oauth2TokenRequestTimeout is the maximum amount of time to wait for the
+	// authorization server to respond to a token request.
+	oauth2TokenRequestTimeout = 10 * time.Second
+
+	// oauth2TokenRetryInterval is how long a failed token request is cached
+	// before the authorization server is contacted again.
+	oauth2TokenRetryInterval = time.Minute
+)
+
+// oauth2Tokens caches the access tokens that were retrieved for sinks so a new
+// access token is only requested when the cached token is about to expire.
+var oauth2Tokens = &oauth2TokenCache{
+	httpClient: newOAuth2HTTPClient(),
+	tokens:     map[string]oauth2TokenResult{},
+}
+
+// oauth2TokenCache caches the results of access token requests keyed by the
+// configuration that was used to request them. Failed requests are cached as
+// well so an authorization server is contacted at most once per retry interval
+// when it's unavailable.
+type oauth2TokenCache struct {
+	httpClient *http.Client
+	requests   singleflight.Group
+
+	mu     sync.Mutex
+	tokens map[string]oauth2TokenResult
+}
+
+// oauth2TokenResult is the result of an access token request.
+type oauth2TokenResult struct {
+	token *oauth2.Token
+	err   error
+	// The time after which the access token should be refreshed. Zero when the
+	// access token doesn't expire.
+	refreshAfter time.Time
+	// The time after which a failed request can be retried.
+	retryAfter time.Time
+}
+
+// valid returns whether the result can be used instead of requesting a new
+// access token.
+func (r oauth2TokenResult) valid() bool {
+	if r.err != nil {
+		return time.Now().Before(r.retryAfter)
+	}
+	return r.refreshAfter.IsZero() || time.Now().Before(r.refreshAfter)
+}
+
+// Token returns a cached access token for the configuration or requests a new
+// access token if there's no cached token or the token is about to expire. The
+// lock is only held while accessing the cache, and concurrent requests for the
+// same configuration share a single token request. The token request isn't
+// cancelled with the context of the caller that started it, since the result
+// is shared with the other callers and cached.
+func (c *oauth2TokenCache) Token(ctx context.Context, config clientcredentials.Config) (*oauth2.Token, error) {
+	key := oauth2TokenCacheKey(config)
+
+	if result, ok := c.cachedToken(key); ok {
+		return result.token, result.err
+	}
+
+	token, err, _ := c.requests.Do(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), oauth2TokenRequestTimeout)
+		defer cancel()
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+
+		result := oauth2TokenResult{}
+		result.token, result.err = config.Token(ctx)
+		if result.err != nil {
+			result.retryAfter = time.Now().Add(oauth2TokenRetryInterval)
+		} else {
+			result.refreshAfter = oauth2TokenRefreshTime(result.token, time.Now())
+		}
+
+		c.mu.Lock()
+		c.tokens[key] = result
+		c.mu.Unlock()
+
+		return result.token, result.err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token.(*oauth2.Token), nil
+}
+
+// cachedToken returns the cached result for the key if it can still be used.
+func (c *oauth2TokenCache) cachedToken(key string) (oauth2TokenResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Remove any results that can no longer be used so the cache doesn't keep
+	// results for configurations that are no longer used.
+	for cacheKey, result := range c.tokens {
+		if !result.valid() {
+			delete(c.tokens, cacheKey)
+		}
+	}
+
+	result, ok := c.tokens[key]
+	return result, ok
+}
+
+// errOAuth2AddressNotAllowed is returned when a token URL resolves to an
+// address the control plane isn't allowed to connect to.
+var errOAuth2AddressNotAllowed = errors.New("the token URL resolves to a private, loopback or link-local address")
+
+// newOAuth2HTTPClient creates the HTTP client used to request access tokens.
+// Tenants choose the token URL, so the client only connects to public
+// addresses over https. The addresses are checked after they're resolved so
+// DNS records can't be used to reach internal services, and proxies are
+// disabled so the check applies to the authorization server itself.
+func newOAuth2HTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: oauth2TokenRequestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !validation.IsPublicAddress(addrPort.Addr()) {
+				return errOAuth2AddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: oauth2TokenRequestTimeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: oauth2TokenRequestTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to a non-https URL is not allowed")
+			} else if len(via) >= 5 {
+				return fmt.Errorf("stopped after 5 redirects")
+			}
+			return nil
+		},
+	}
+}
+
+// oauth2TokenRefreshTime returns when the token that was retrieved at the
+// given time should be refreshed. The refresh window is scaled down for tokens
+// with short lifetimes so they're still cached for half their lifetime. Tokens
+// without an expiry are never refreshed.
+func oauth2TokenRefreshTime(token *oauth2.Token, retrievedAt time.Time) time.Time {
+	if token.Expiry.IsZero() {
+		return time.Time{}
+	}
+	window := min(oauth2TokenRefreshWindow, token.Expiry.Sub(retrievedAt)/2)
+	return token.Expiry.Add(-max(window, 0))
+}
+
+// oauth2TokenCacheKey returns a unique key for the token request configuration.
+func oauth2TokenCacheKey(config clientcredentials.Config) string {
+	hash := sha256.New()
+	for _, value := range []string{
+		config.TokenURL,
+		config.ClientID,
+		config.ClientSecret,
+		strings.Join(config.Scopes, " "),
+		config.EndpointParams.Encode(),
+	} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// retrieveOAuth2Token retrieves an access token for the sink using the OAuth2
+// client credentials flow. The client credentials are retrieved from the
+// secret referenced by the sink.
+func retrieveOAuth2Token(ctx context.Context, client client.Client, auth v1alpha1.OAuth2Authentication, exportPolicy *v1alpha1.ExportPolicy) (*oauth2.Token, error) {
+	secret, err := retrieveSecret(ctx, client, auth.ClientSecretRef.Name, exportPolicy)
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+	}
+
+	clientID, clientSecret := secret.Data["client-id"], secret.Data["client-secret"]
+	if len(clientID) == 0 {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidAuthentication",
+			err:    fmt.Errorf("secret '%s' does not contain a client-id", auth.ClientSecretRef.Name),
+		}
+	} else if len(clientSecret) == 0 {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidAuthentication",
+			err:    fmt.Errorf("secret '%s' does not contain a client-secret", auth.ClientSecretRef.Name),
+		}
+	}
+
+	// Validation rejects token URLs that don't use https, but policies created
+	// before the validation was added may still use them.
+	if tokenURL, err := url.Parse(auth.TokenURL); err != nil || tokenURL.Scheme != "https" {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidAuthentication",
+			err:    fmt.Errorf("the token URL must use the https scheme"),
+		}
+	}
+
+	config := clientcredentials.Config{
+		ClientID:       string(clientID),
+		ClientSecret:   string(clientSecret),
+		TokenURL:       auth.TokenURL,
+		Scopes:         auth.Scopes,
+		EndpointParams: url.Values{},
+	}
+	if auth.Audience != "" {
+		config.EndpointParams.Set("audience", auth.Audience)
+	}
+
+	// The error is reported in the sink's status, so the response of the
+	// authorization server is only logged to avoid returning the responses of
+	// arbitrary endpoints to the tenant.
+	token, err := oauth2Tokens.Token(ctx, config)
+	if err != nil {
+		log.FromContext(ctx).Info("failed to retrieve an OAuth2 access token", "tokenURL", auth.TokenURL, "error", err.Error())
+		return nil, &sinkConfigurationError{
+			reason: "TokenRequestFailed",
+			err:    fmt.Errorf("failed to retrieve an access token from '%s': %s", auth.TokenURL, oauth2TokenErrorMessage(err)),
+		}
+	}
+
+	return token, nil
+}
+
+// oauth2TokenErrorMessage returns a description of a failed token request that
+// doesn't include the response of the authorization server.
+func oauth2TokenErrorMessage(err error) string {
+	var retrieveErr *oauth2.RetrieveError
+	switch {
+	case errors.Is(err, errOAuth2AddressNotAllowed):
+		return errOAuth2AddressNotAllowed.Error()
+	case errors.As(err, &retrieveErr) && retrieveErr.Response != nil:
+		return fmt.Sprintf("the authorization server responded with status %d", retrieveErr.Response.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "the authorization server did not respond in time"
+	}
+	return "the authorization server could not be reached"
+}
+
+// usesOAuth2Authentication returns whether any of the sinks in the export
+// policy authenticate using OAuth2 access tokens.
+func usesOAuth2Authentication(exportPolicy *v1alpha1.ExportPolicy) bool {
+	for _, sink := range exportPolicy.Spec.Sinks {
+		if sink.Target == nil {
+			continue
+		}
+
+		var auth *v1alpha1.Authentication
+		switch {
+		case sink.Target.PrometheusRemoteWrite != nil:
+			auth = sink.Target.PrometheusRemoteWrite.Authentication
+		case sink.Target.OpenTelemetry != nil && sink.Target.OpenTelemetry.HTTP != nil:
+			auth = sink.Target.OpenTelemetry.HTTP.Authentication
+		case sink.Target.Loki != nil:
+			auth = sink.Target.Loki.Authentication
+		}
+
+		if auth != nil && auth.OAuth2 != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+)
+
+func TestRetrieveOAuth2Token(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := requests.Add(1)
+
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		clientID, _, ok := r.BasicAuth()
+		if !ok {
+			clientID = r.Form.Get("client_id")
+		}
+		if clientID == "rejected" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, "internal response details")
+			return
+		}
+
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("audience") != "metrics" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 3600}`, count)
+	}))
+	defer server.Close()
+
+	// The test server listens on a loopback address, so the client that's used
+	// in production would refuse to connect to it.
+	cache := oauth2Tokens
+	oauth2Tokens = &oauth2TokenCache{
+		httpClient: server.Client(),
+		tokens:     map[string]oauth2TokenResult{},
+	}
+	defer func() { oauth2Tokens = cache }()
+
+	exportPolicy := newExportPolicy()
+	auth := v1alpha1.OAuth2Authentication{
+		TokenURL: server.URL,
+		ClientSecretRef: v1alpha1.LocalSecretReference{
+			Name: "oauth2-client",
+		},
+		Audience: "metrics",
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oauth2-client", Namespace: exportPolicy.Namespace},
+			Data: map[string][]byte{
+				"client-id":     []byte("client"),
+				"client-secret": []byte("secret"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "rejected-client", Namespace: exportPolicy.Namespace},
+			Data: map[string][]byte{
+				"client-id":     []byte("rejected"),
+				"client-secret": []byte("secret"),
+			},
+		},
+	).Build()
+
+	token, err := retrieveOAuth2Token(context.Background(), fakeClient, auth, exportPolicy)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "token-1", token.AccessToken)
+	}
+
+	// The token should be cached until it's about to expire.
+	token, err = retrieveOAuth2Token(context.Background(), fakeClient, auth, exportPolicy)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "token-1", token.AccessToken)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	// The response of the authorization server should not be included in the
+	// error, and the failure should be cached.
+	requests.Store(0)
+	rejected := auth
+	rejected.ClientSecretRef.Name = "rejected-client"
+	for range 2 {
+		_, err = retrieveOAuth2Token(context.Background(), fakeClient, rejected, exportPolicy)
+		var configErr *sinkConfigurationError
+		if assert.ErrorAs(t, err, &configErr) {
+			assert.Equal(t, "TokenRequestFailed", configErr.reason)
+			assert.Contains(t, configErr.Error(), "status 401")
+			assert.NotContains(t, configErr.Error(), "internal response details")
+		}
+	}
+	// The client tries sending the credentials in the header and then in the
+	// request body, so a single rejected token request makes two requests.
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Token URLs that don't use https should be rejected.
+	insecure := auth
+	insecure.TokenURL = strings.Replace(server.URL, "https://", "http://", 1)
+	_, err = retrieveOAuth2Token(context.Background(), fakeClient, insecure, exportPolicy)
+	var configErr *sinkConfigurationError
+	if assert.ErrorAs(t, err, &configErr) {
+		assert.Equal(t, "InvalidAuthentication", configErr.reason)
+	}
+
+	// A missing client secret should be reported as invalid authentication.
+	auth.ClientSecretRef.Name = "missing"
+	_, err = retrieveOAuth2Token(context.Background(), fakeClient, auth, exportPolicy)
+	if assert.ErrorAs(t, err, &configErr) {
+		assert.Equal(t, "InvalidAuthentication", configErr.reason)
+	}
+}
+
+func TestOAuth2HTTPClientRejectsInternalAddresses(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	cache := &oauth2TokenCache{
+		httpClient: newOAuth2HTTPClient(),
+		tokens:     map[string]oauth2TokenResult{},
+	}
+
+	_, err := cache.Token(context.Background(), clientcredentials.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+	})
+	if assert.ErrorIs(t, err, errOAuth2AddressNotAllowed) {
+		assert.Equal(t, errOAuth2AddressNotAllowed.Error(), oauth2TokenErrorMessage(err))
+	}
+	assert.Zero(t, requests.Load())
+}
+
+func TestOAuth2TokenRefreshTime(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, oauth2TokenRefreshTime(&oauth2.Token{}, now).IsZero())
+	assert.Equal(t, now.Add(55*time.Minute), oauth2TokenRefreshTime(&oauth2.Token{Expiry: now.Add(time.Hour)}, now))
+	// Short-lived tokens are cached for half their lifetime instead of being
+	// requested on every reconcile.
+	assert.Equal(t, now.Add(2*time.Minute), oauth2TokenRefreshTime(&oauth2.Token{Expiry: now.Add(4 * time.Minute)}, now))
+	assert.Equal(t, now.Add(-time.Minute), oauth2TokenRefreshTime(&oauth2.Token{Expiry: now.Add(-time.Minute)}, now))
+}
+
+func TestOAuth2TokenCacheIgnoresCallerCancellation(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	cache := &oauth2TokenCache{
+		httpClient: server.Client(),
+		tokens:     map[string]oauth2TokenResult{},
+	}
+	config := clientcredentials.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+	}
+
+	// The caller that starts the token request is cancelled before the
+	// authorization server responds. The result is shared with every caller
+	// that waits for the request and cached, so the request isn't cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Token(ctx, config)
+		firstErr <- err
+	}()
+	<-started
+	cancel()
+	close(release)
+	assert.NoError(t, <-firstErr)
+
+	token, err := cache.Token(context.Background(), config)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "token", token.AccessToken)
+	}
+}
@@ -0,0 +1,441 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+	"go.datum.net/telemetry-services-operator/internal/validation"
+)
+
+// chainedTransform is a vector transform that's chained together with other
+// transforms of a source or sink. The name is unique within the source or sink
+// and is used to create the ID of the transform component.
+type chainedTransform struct {
+	name   string
+	config map[string]any
+}
+
+// chainTransforms chains the transforms together so each transform uses the
+// previous transform as its input, starting with the given inputs. The
+// transforms are returned keyed by their component IDs along with the inputs
+// for the component that consumes the output of the chain.
+func chainTransforms(exportPolicy *v1alpha1.ExportPolicy, projectName, componentName string, inputs []string, transforms []chainedTransform) (map[string]any, []string) {
+	configs := map[string]any{}
+	for _, transform := range transforms {
+		transformID := getVectorComponentID(exportPolicy, projectName, componentName+"-"+transform.name, vectorTransform)
+		transform.config["inputs"] = inputs
+		configs[transformID] = transform.config
+		inputs = []string{transformID}
+	}
+	return configs, inputs
+}
+
+// getSinkTransforms returns the vector transforms that are configured for the
+// sink in the order they're applied. The transforms don't include any inputs
+// since they're chained together when the sink is added to the vector
+// configuration.
+func getSinkTransforms(sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) ([]chainedTransform, error) {
+	signal := sinkSignal(sink, exportPolicy)
+	if (sink.Transforms != nil || sink.CardinalityLimit != nil) && signal != validation.SignalMetrics {
+		return nil, &sinkConfigurationError{
+			reason: "UnsupportedTransform",
+			err:    fmt.Errorf("transforms are not supported for sinks that publish %s", signal),
+		}
+	}
+
+	transforms := []chainedTransform{}
+	if sink.Transforms != nil {
+		configured, err := getMetricTransforms(*sink.Transforms)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, configured...)
+	}
+
+	// The cardinality limit is applied last so it limits the labels that are
+	// published to the sink.
+	if sink.CardinalityLimit != nil {
+		limit, err := getCardinalityLimitVectorConfig(*sink.CardinalityLimit)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, chainedTransform{name: cardinalityLimitTransformName, config: limit})
+	}
+
+	// Some sink targets can only publish log events, so metrics are converted
+	// into log events after all other transforms are applied.
+	if signal == validation.SignalMetrics && sinkTargetRequiresLogEvents(sink.Target) {
+		transforms = append(transforms, chainedTransform{
+			name: "metric-to-log",
+			config: map[string]any{
+				"type": "metric_to_log",
+			},
+		})
+
+		// Vector encodes log events into OTLP requests field by field, so the
+		// events must be shaped like an OTLP export request.
+		if sink.Target.OpenTelemetry != nil {
+			transforms = append(transforms, chainedTransform{
+				name: "otlp-metrics",
+				config: map[string]any{
+					"type":          "remap",
+					"source":        otlpMetricsRemapSource,
+					"drop_on_abort": true,
+				},
+			})
+		}
+	}
+
+	return transforms, nil
+}
+
+// sinkTargetRequiresLogEvents returns whether the vector sink of the target
+// only accepts log events.
+func sinkTargetRequiresLogEvents(target *v1alpha1.SinkTarget) bool {
+	return target != nil && (target.ObjectStorage != nil || target.OpenTelemetry != nil)
+}
+
+// otlpMetricsRemapSource converts a metric that was converted into a log event
+// by the metric_to_log transform into an OTLP metrics export request. Metric
+// tags become data point attributes and the metric kind is kept as the
+// aggregation temporality. Metric types that can't be represented in OTLP are
+// dropped.
+const otlpMetricsRemapSource = `name = string(.name) ?? ""
+if is_string(.namespace) {
+  name = string!(.namespace) + "_" + name
+}
+
+attributes = []
+for_each(object(.tags) ?? {}) -> |key, value| {
+  attributes = push(attributes, {"key": key, "value": {"string_value": string(value) ?? encode_json(value)}})
+}
+
+# 1 is the delta and 2 the cumulative aggregation temporality.
+temporality = 2
+if .kind == "incremental" {
+  temporality = 1
+}
+
+data_point = {
+  "attributes": attributes,
+  "time_unix_nano": to_unix_timestamp(timestamp(.timestamp) ?? now(), unit: "nanoseconds")
+}
+metric = {"name": name}
+if exists(.gauge) {
+  data_point.as_double = to_float(.gauge.value) ?? 0.0
+  metric.gauge = {"data_points": [data_point]}
+} else if exists(.counter) {
+  data_point.as_double = to_float(.counter.value) ?? 0.0
+  metric.sum = {"data_points": [data_point], "aggregation_temporality": temporality, "is_monotonic": true}
+} else if exists(.aggregated_histogram) {
+  bounds = []
+  bucket_counts = []
+  bucketed = 0
+  for_each(array(.aggregated_histogram.buckets) ?? []) -> |_index, bucket| {
+    bounds = push(bounds, to_float(bucket.upper_limit) ?? 0.0)
+    bucket_count = to_int(bucket.count) ?? 0
+    bucket_counts = push(bucket_counts, bucket_count)
+    bucketed = bucketed + bucket_count
+  }
+
+  # OTLP histograms have an overflow bucket for values above the last bound.
+  count = to_int(.aggregated_histogram.count) ?? bucketed
+  overflow = count - bucketed
+  if overflow < 0 {
+    overflow = 0
+  }
+
+  data_point.count = count
+  data_point.sum = to_float(.aggregated_histogram.sum) ?? 0.0
+  data_point.bucket_counts = push(bucket_counts, overflow)
+  data_point.explicit_bounds = bounds
+  metric.histogram = {"data_points": [data_point], "aggregation_temporality": temporality}
+} else if exists(.aggregated_summary) {
+  quantiles = []
+  for_each(array(.aggregated_summary.quantiles) ?? []) -> |_index, quantile| {
+    quantiles = push(quantiles, {"quantile": to_float(quantile.quantile) ?? 0.0, "value": to_float(quantile.value) ?? 0.0})
+  }
+
+  data_point.count = to_int(.aggregated_summary.count) ?? 0
+  data_point.sum = to_float(.aggregated_summary.sum) ?? 0.0
+  data_point.quantile_values = quantiles
+  metric.summary = {"data_points": [data_point]}
+} else {
+  abort
+}
+
+. = {"resource_metrics": [{"scope_metrics": [{"metrics": [metric]}]}]}
+`
+
+// cardinalityLimitTransformName is the name of the transform that limits the
+// cardinality of a sink's metrics.
+const cardinalityLimitTransformName = "cardinality-limit"
+
+// getCardinalityLimitVectorConfig creates the configuration of a vector
+// tag_cardinality_limit transform for the cardinality limit.
+func getCardinalityLimitVectorConfig(limit v1alpha1.CardinalityLimit) (map[string]any, error) {
+	if limit.ValueLimit < 1 {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidCardinalityLimit",
+			err:    fmt.Errorf("the cardinality value limit must be at least 1"),
+		}
+	}
+
+	var action string
+	switch limit.Mode {
+	case v1alpha1.CardinalityLimitModeDropTag, "":
+		action = "drop_tag"
+	case v1alpha1.CardinalityLimitModeDropEvent:
+		action = "drop_event"
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidCardinalityLimit",
+			err:    fmt.Errorf("cardinality limit mode '%s' is not supported", limit.Mode),
+		}
+	}
+
+	return map[string]any{
+		"type":                  "tag_cardinality_limit",
+		"mode":                  "exact",
+		"value_limit":           limit.ValueLimit,
+		"limit_exceeded_action": action,
+	}, nil
+}
+
+// getMetricTransforms returns the vector transforms that filter and change the
+// labels of metrics in the order they're applied.
+func getMetricTransforms(sinkTransforms v1alpha1.SinkTransforms) ([]chainedTransform, error) {
+	transforms := []chainedTransform{}
+
+	condition, err := getMetricNameFilterCondition(sinkTransforms)
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidTransform", err: err}
+	}
+	if condition != "" {
+		transforms = append(transforms, chainedTransform{
+			name: "filter",
+			config: map[string]any{
+				"type":      "filter",
+				"condition": condition,
+			},
+		})
+	}
+
+	if source := getMetricLabelsRemapSource(sinkTransforms); source != "" {
+		transforms = append(transforms, chainedTransform{
+			name: "labels",
+			config: map[string]any{
+				"type":   "remap",
+				"source": source,
+			},
+		})
+	}
+
+	if len(sinkTransforms.RelabelConfigs) > 0 {
+		source, err := getRelabelRemapSource(sinkTransforms.RelabelConfigs)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidTransform", err: err}
+		}
+		transforms = append(transforms, chainedTransform{
+			name: "relabel",
+			config: map[string]any{
+				"type":          "remap",
+				"source":        source,
+				"drop_on_abort": true,
+			},
+		})
+	}
+
+	return transforms, nil
+}
+
+// getMetricNameFilterCondition creates a VRL condition that only passes metrics
+// with names that are included and not excluded by the transforms. Returns an
+// empty condition when metrics are not filtered by name.
+func getMetricNameFilterCondition(transforms v1alpha1.SinkTransforms) (string, error) {
+	conditions := []string{}
+	if len(transforms.IncludeMetrics) > 0 {
+		patterns, err := vrlAnchoredRegexes(transforms.IncludeMetrics)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("match_any(name, [%s])", patterns))
+	}
+
+	if len(transforms.ExcludeMetrics) > 0 {
+		patterns, err := vrlAnchoredRegexes(transforms.ExcludeMetrics)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("!match_any(name, [%s])", patterns))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("name = string(.name) ?? \"\"\n%s\n", strings.Join(conditions, " && ")), nil
+}
+
+// getMetricLabelsRemapSource creates a VRL program that drops, keeps and adds
+// the labels of metrics. Returns an empty program when labels are not changed.
+func getMetricLabelsRemapSource(transforms v1alpha1.SinkTransforms) string {
+	var source strings.Builder
+	if len(transforms.DropLabels) > 0 {
+		fmt.Fprintf(&source, ".tags = filter(object(.tags) ?? {}) -> |key, _value| { !includes([%s], key) }\n", vrlStrings(transforms.DropLabels))
+	}
+
+	if len(transforms.KeepLabels) > 0 {
+		fmt.Fprintf(&source, ".tags = filter(object(.tags) ?? {}) -> |key, _value| { includes([%s], key) }\n", vrlStrings(transforms.KeepLabels))
+	}
+
+	for _, label := range transforms.AddLabels {
+		fmt.Fprintf(&source, ".tags.%s = %s\n", vrlString(label.Name), vrlString(label.Value))
+	}
+
+	return source.String()
+}
+
+// getRelabelRemapSource compiles the relabel configs into a VRL program. The
+// metric's tags and name are copied into a labels object so the relabel
+// configs can treat the metric name as the `__name__` label, the same as
+// Prometheus. Metrics that are dropped by a relabel config are aborted.
+func getRelabelRemapSource(relabelConfigs []v1alpha1.RelabelConfig) (string, error) {
+	var source strings.Builder
+	source.WriteString("labels = object(.tags) ?? {}\n")
+	source.WriteString("labels.__name__ = .name\n")
+
+	for index, relabelConfig := range relabelConfigs {
+		rule, err := getRelabelConfigVRL(relabelConfig)
+		if err != nil {
+			return "", fmt.Errorf("relabel config %d: %w", index, err)
+		}
+		source.WriteString(rule)
+	}
+
+	source.WriteString(".name = string(labels.__name__) ?? .name\n")
+	source.WriteString("del(labels.__name__)\n")
+	source.WriteString(".tags = labels\n")
+	return source.String(), nil
+}
+
+// getRelabelConfigVRL compiles a single relabel config into VRL statements that
+// read and update the labels object.
+func getRelabelConfigVRL(relabelConfig v1alpha1.RelabelConfig) (string, error) {
+	separator := relabelConfig.Separator
+	if separator == "" {
+		separator = ";"
+	}
+
+	pattern := relabelConfig.Regex
+	if pattern == "" {
+		pattern = "(.*)"
+	}
+	regex, err := vrlAnchoredRegex(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	replacement := "$1"
+	if relabelConfig.Replacement != nil {
+		replacement = *relabelConfig.Replacement
+	}
+
+	// The values of the source labels are concatenated using the separator.
+	// Missing labels are treated as empty values.
+	values := []string{}
+	for _, label := range relabelConfig.SourceLabels {
+		values = append(values, fmt.Sprintf("(string(labels.%s) ?? \"\")", vrlString(label)))
+	}
+	value := `""`
+	if len(values) > 0 {
+		value = strings.Join(values, " + "+vrlString(separator)+" + ")
+	}
+
+	switch relabelConfig.Action {
+	case v1alpha1.RelabelActionReplace, "":
+		if relabelConfig.TargetLabel == "" {
+			return "", fmt.Errorf("the replace action requires a target label")
+		}
+		target := "labels." + vrlString(relabelConfig.TargetLabel)
+		return fmt.Sprintf(`value = %s
+if match(value, %s) {
+  %s = replace(value, %s, %s)
+  if %s == "" {
+    del(%s)
+  }
+}
+`, value, regex, target, regex, vrlString(replacement), target, target), nil
+	case v1alpha1.RelabelActionKeep:
+		return fmt.Sprintf("if !match(%s, %s) {\n  abort\n}\n", value, regex), nil
+	case v1alpha1.RelabelActionDrop:
+		return fmt.Sprintf("if match(%s, %s) {\n  abort\n}\n", value, regex), nil
+	case v1alpha1.RelabelActionLabelMap:
+		return fmt.Sprintf(`for_each(labels) -> |key, label_value| {
+  if match(key, %s) {
+    labels = set!(labels, [replace(key, %s, %s)], label_value)
+  }
+}
+`, regex, regex, vrlString(replacement)), nil
+	case v1alpha1.RelabelActionLabelDrop:
+		return fmt.Sprintf("labels = filter(labels) -> |key, _value| { key == \"__name__\" || !match(key, %s) }\n", regex), nil
+	case v1alpha1.RelabelActionLabelKeep:
+		return fmt.Sprintf("labels = filter(labels) -> |key, _value| { key == \"__name__\" || match(key, %s) }\n", regex), nil
+	case v1alpha1.RelabelActionHashMod:
+		if relabelConfig.TargetLabel == "" {
+			return "", fmt.Errorf("the hashmod action requires a target label")
+		}
+		if relabelConfig.Modulus < 1 || relabelConfig.Modulus > math.MaxInt32 {
+			return "", fmt.Errorf("the hashmod action requires a modulus between 1 and %d", math.MaxInt32)
+		}
+
+		// Prometheus uses the last 8 bytes of the MD5 hash of the value as an
+		// unsigned 64 bit integer. VRL integers are signed, so the modulus is
+		// calculated from the upper and lower 32 bits of the integer.
+		modulus := relabelConfig.Modulus
+		upperFactor := (1 << 32) % modulus
+		return fmt.Sprintf(`hash = md5(%s)
+labels.%s = to_string(((parse_int!(slice!(hash, 16, 24), 16) %% %d) * %d + parse_int!(slice!(hash, 24), 16)) %% %d)
+`, value, vrlString(relabelConfig.TargetLabel), modulus, upperFactor, modulus), nil
+	}
+
+	return "", fmt.Errorf("relabel action '%s' is not supported", relabelConfig.Action)
+}
+
+// vrlStrings returns the values as a comma separated list of VRL string
+// literals.
+func vrlStrings(values []string) string {
+	literals := []string{}
+	for _, value := range values {
+		literals = append(literals, vrlString(value))
+	}
+	return strings.Join(literals, ", ")
+}
+
+// vrlAnchoredRegexes returns the patterns as a comma separated list of VRL
+// regex literals that must match the entire value.
+func vrlAnchoredRegexes(patterns []string) (string, error) {
+	literals := []string{}
+	for _, pattern := range patterns {
+		literal, err := vrlAnchoredRegex(pattern)
+		if err != nil {
+			return "", err
+		}
+		literals = append(literals, literal)
+	}
+	return strings.Join(literals, ", "), nil
+}
+
+// vrlAnchoredRegex returns the pattern as a VRL regex literal that must match
+// the entire value.
+func vrlAnchoredRegex(pattern string) (string, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("invalid regular expression '%s': %w", pattern, err)
+	}
+	return "r'^(?:" + strings.ReplaceAll(pattern, "'", `\'`) + ")$'", nil
+}
@@ -2,8 +2,13 @@ package controller
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/VictoriaMetrics/metricsql"
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+	"go.datum.net/telemetry-services-operator/internal/validation"
 )
 
 // createVectorConfiguration creates a vector configuration for the export policy
@@ -28,85 +34,586 @@ import (
 func (r *ExportPolicyReconciler) createVectorConfiguration(ctx context.Context, projectName string, client client.Client, exportPolicy *v1alpha1.ExportPolicy) map[string]any {
 	// Create a vector configuration for each source and sink combination
 	vectorConfig := map[string]any{
-		"sources": make(map[string]any),
-		"sinks":   make(map[string]any),
+		"sources":    make(map[string]any),
+		"transforms": make(map[string]any),
+		"sinks":      make(map[string]any),
 	}
 
-	// Configure the sources that will be used to export the metrics from the
-	// telemetry sources to the configured sinks.
-	sources := vectorConfig["sources"].(map[string]any)
+	// Configure the sources that will be used to export the telemetry data from
+	// the telemetry sources to the configured sinks. Trace sources select spans
+	// from the platform's trace pipeline, so they're configured as transforms.
+	// The sink inputs are looked up by the source name since the component
+	// that outputs the source's telemetry depends on the source type.
+	sourceOutputs := map[string]string{}
 	for _, source := range exportPolicy.Spec.Sources {
-		if source.Metrics == nil {
+		sourceConfig, err := r.getSourceVectorConfig(projectName, source)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to get vector configuration for source", "source", source.Name)
 			continue
 		}
 
-		query, err := metricsql.Parse(source.Metrics.MetricsQL)
+		componentKind := "sources"
+		if source.Traces != nil {
+			componentKind = "transforms"
+		}
+
+		sourceID := getVectorComponentID(exportPolicy, projectName, source.Name, vectorSource)
+		vectorConfig[componentKind].(map[string]any)[sourceID] = sourceConfig
+		sourceOutputs[source.Name] = sourceID
+
+		// Aggregated metric sources query the metrics service for the
+		// aggregates, so the query results are converted into metrics by
+		// transforms.
+		if source.Metrics != nil && source.Metrics.Aggregation != nil {
+			transformConfigs, outputs := chainTransforms(exportPolicy, projectName, source.Name, []string{sourceID}, getMetricAggregationTransforms(*source.Metrics.Aggregation))
+			maps.Copy(vectorConfig["transforms"].(map[string]any), transformConfigs)
+			sourceOutputs[source.Name] = outputs[0]
+		}
+	}
+
+	// Configure sinks along with the transforms that are applied to the
+	// telemetry of each sink.
+	sinks := vectorConfig["sinks"].(map[string]any)
+
+	for _, sink := range exportPolicy.Spec.Sinks {
+		sinkConfig, sinkTransforms, err := getSinkVectorConfig(ctx, client, projectName, sink, exportPolicy, sourceOutputs)
 		if err != nil {
-			log.FromContext(ctx, "source", source.Name).Error(err, "unable to parse metricsql query")
+			log.FromContext(ctx).Error(err, "failed to get vector configuration for sink", "sink", sink.Name)
 			continue
 		}
 
-		metricExpr, ok := query.(*metricsql.MetricExpr)
-		if !ok {
-			log.FromContext(ctx, "source", source.Name).Error(fmt.Errorf("failed to convert metricsql query to MetricExpress"), "")
+		maps.Copy(vectorConfig["transforms"].(map[string]any), sinkTransforms)
+		sinks[getVectorComponentID(exportPolicy, projectName, sink.Name, vectorSink)] = sinkConfig
+	}
+
+	return vectorConfig
+}
+
+// platformTracesComponentID is the ID of the vector source that receives the
+// platform's spans over OTLP. The source is configured by the vector traces
+// component, which is only deployed when the trace receiver is enabled.
+const platformTracesComponentID = "platform_traces.traces"
+
+// getTraceSourceVectorConfig creates a vector configuration that selects the
+// project's spans from the platform's trace pipeline. The platform's spans are
+// received as OTLP export requests, so the resource spans of each request are
+// filtered down to the resources selected by the source and requests without
+// any remaining resource spans are dropped.
+func (r *ExportPolicyReconciler) getTraceSourceVectorConfig(projectName string, traces v1alpha1.TraceSource) (map[string]any, error) {
+	if !r.TracesService.Enabled {
+		return nil, &sourceConfigurationError{
+			reason: "Unsupported",
+			err:    fmt.Errorf("trace sources are not supported because the platform trace receiver is not enabled"),
 		}
+	}
 
-		// Default to the project name as the label filter so that all metrics for
-		// the project are exported.
-		if len(metricExpr.LabelFilterss) == 0 {
-			metricExpr.LabelFilterss = [][]metricsql.LabelFilter{
-				{
-					{
-						Label: "resourcemanager_datumapis_com_project_name",
-						Value: projectName,
-					},
-				},
+	conditions := []string{
+		fmt.Sprintf("attributes.%s == %s", vrlString(validation.TraceProjectAttribute), vrlString(projectName)),
+	}
+
+	if len(traces.ServiceNames) > 0 {
+		serviceNames := []string{}
+		for _, serviceName := range traces.ServiceNames {
+			serviceNames = append(serviceNames, vrlString(serviceName))
+		}
+		conditions = append(conditions, fmt.Sprintf(`includes([%s], attributes."service.name")`, strings.Join(serviceNames, ", ")))
+	}
+
+	for _, attribute := range traces.ResourceAttributes {
+		conditions = append(conditions, fmt.Sprintf("attributes.%s == %s", vrlString(attribute.Key), vrlString(attribute.Value)))
+	}
+
+	return map[string]any{
+		"type":   "remap",
+		"inputs": []string{platformTracesComponentID},
+		"source": fmt.Sprintf(`.resource_spans = filter(array(.resource_spans) ?? []) -> |_index, resource_spans| {
+  attributes = {}
+  for_each(array(resource_spans.resource.attributes) ?? []) -> |_index, attribute| {
+    attributes = set!(attributes, [string!(attribute.key)], attribute.value.string_value)
+  }
+  %s
+}
+
+if length(.resource_spans) == 0 {
+  abort
+}
+`, strings.Join(conditions, " &&\n  ")),
+		"drop_on_abort": true,
+	}, nil
+}
+
+// vrlString returns the value as a quoted VRL string literal.
+func vrlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(value) + `"`
+}
+
+// sourceConfigurationError is returned when a source's configuration can't be
+// translated into a vector configuration. The reason is used as the reason of
+// the source's Accepted condition.
+type sourceConfigurationError struct {
+	reason string
+	err    error
+}
+
+func (e *sourceConfigurationError) Error() string {
+	return e.err.Error()
+}
+
+// getSourceVectorConfig creates a vector configuration for the given source.
+func (r *ExportPolicyReconciler) getSourceVectorConfig(projectName string, source v1alpha1.TelemetrySource) (map[string]any, error) {
+	if err := checkSourceConfiguration(source); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case source.Logs != nil:
+		return r.getLogSourceVectorConfig(projectName, *source.Logs)
+	case source.Traces != nil:
+		return r.getTraceSourceVectorConfig(projectName, *source.Traces)
+	}
+	return r.getMetricSourceVectorConfig(projectName, source)
+}
+
+// checkSourceConfiguration confirms the source's configuration can be
+// translated into a vector configuration.
+func checkSourceConfiguration(source v1alpha1.TelemetrySource) error {
+	var signals int
+	for _, configured := range []bool{source.Metrics != nil, source.Logs != nil, source.Traces != nil} {
+		if configured {
+			signals++
+		}
+	}
+
+	switch {
+	case signals > 1:
+		return &sourceConfigurationError{
+			reason: "InvalidSource",
+			err:    fmt.Errorf("source can only configure one of metrics, logs or traces"),
+		}
+	case source.Metrics != nil:
+		if _, err := parseMetricSourceQuery(source); err != nil {
+			return err
+		}
+		if source.Metrics.Aggregation != nil {
+			if err := checkMetricAggregation(*source.Metrics.Aggregation); err != nil {
+				return &sourceConfigurationError{reason: "InvalidAggregation", err: err}
+			}
+		}
+		return nil
+	case source.Logs != nil:
+		if err := validation.CheckLogsQLFilter(source.Logs.LogsQL); err != nil {
+			return &sourceConfigurationError{reason: "InvalidQuery", err: err}
+		}
+		return nil
+	case source.Traces != nil:
+		for _, attribute := range source.Traces.ResourceAttributes {
+			if attribute.Key == validation.TraceProjectAttribute {
+				return &sourceConfigurationError{
+					reason: "InvalidQuery",
+					err:    fmt.Errorf("resource attribute '%s' can not be selected", attribute.Key),
+				}
 			}
-		} else {
-			for i := range metricExpr.LabelFilterss {
-				metricExpr.LabelFilterss[i] = append(metricExpr.LabelFilterss[i], metricsql.LabelFilter{
+		}
+		return nil
+	}
+
+	return &sourceConfigurationError{
+		reason: "InvalidSource",
+		err:    fmt.Errorf("source does not configure any metrics, logs or traces"),
+	}
+}
+
+// getMetricSourceVectorConfig creates a vector configuration that scrapes the
+// metrics selected by the source from the metrics service.
+func (r *ExportPolicyReconciler) getMetricSourceVectorConfig(projectName string, source v1alpha1.TelemetrySource) (map[string]any, error) {
+	metricExpr, err := parseMetricSourceQuery(source)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default to the project name as the label filter so that all metrics for
+	// the project are exported.
+	if len(metricExpr.LabelFilterss) == 0 {
+		metricExpr.LabelFilterss = [][]metricsql.LabelFilter{
+			{
+				{
 					Label: "resourcemanager_datumapis_com_project_name",
 					Value: projectName,
-				})
-			}
+				},
+			},
+		}
+	} else {
+		for i := range metricExpr.LabelFilterss {
+			metricExpr.LabelFilterss[i] = append(metricExpr.LabelFilterss[i], metricsql.LabelFilter{
+				Label: "resourcemanager_datumapis_com_project_name",
+				Value: projectName,
+			})
+		}
+	}
+
+	marshalledQuery := []byte{}
+	marshalledQuery = metricExpr.AppendString(marshalledQuery)
+
+	if source.Metrics.Aggregation != nil {
+		return r.getAggregatedMetricSourceVectorConfig(string(marshalledQuery), *source.Metrics.Aggregation)
+	}
+
+	return map[string]any{
+		"type":      "prometheus_scrape",
+		"endpoints": []string{r.MetricsService.Endpoint},
+		"auth": map[string]any{
+			"strategy": "basic",
+			"user":     r.MetricsService.Username,
+			"password": r.MetricsService.Password,
+		},
+		"query": map[string]any{
+			"match[]": []string{string(marshalledQuery)},
+		},
+	}, nil
+}
+
+// defaultMetricAggregationWindow is the rollup window used when an aggregation
+// doesn't configure a window.
+const defaultMetricAggregationWindow = time.Minute
+
+// getAggregatedMetricSourceVectorConfig creates a vector configuration that
+// queries the metrics service for the aggregates of the selected metrics at the
+// end of every rollup window. The federate endpoint only supports selecting
+// series, so the aggregates are queried from the query API instead and the
+// results are converted into metrics by the transforms returned by
+// getMetricAggregationTransforms.
+func (r *ExportPolicyReconciler) getAggregatedMetricSourceVectorConfig(selector string, aggregation v1alpha1.MetricAggregation) (map[string]any, error) {
+	if !r.MetricsService.QueryEnabled() {
+		return nil, &sourceConfigurationError{
+			reason: "Unsupported",
+			err:    fmt.Errorf("metric aggregation is not supported because the metrics query service is not configured"),
+		}
+	}
+
+	query, err := getMetricAggregationQuery(selector, aggregation)
+	if err != nil {
+		return nil, &sourceConfigurationError{reason: "InvalidAggregation", err: err}
+	}
+
+	return map[string]any{
+		"type":                 "http_client",
+		"endpoint":             strings.TrimSuffix(r.MetricsService.QueryEndpoint, "/") + "/api/v1/query",
+		"method":               "GET",
+		"scrape_interval_secs": int64(metricAggregationWindow(aggregation).Seconds()),
+		"auth": map[string]any{
+			"strategy": "basic",
+			"user":     r.MetricsService.Username,
+			"password": r.MetricsService.Password,
+		},
+		"query": map[string]any{
+			"query": []string{query},
+		},
+		"decoding": map[string]any{
+			"codec": "json",
+		},
+	}, nil
+}
+
+// getMetricAggregationQuery creates a metricsql query that aggregates the
+// series selected by the selector over the aggregation's rollup window. The
+// metrics are always grouped by their name and project.
+func getMetricAggregationQuery(selector string, aggregation v1alpha1.MetricAggregation) (string, error) {
+	if err := checkMetricAggregation(aggregation); err != nil {
+		return "", err
+	}
+
+	var aggregate, rollup string
+	switch aggregation.Function {
+	case v1alpha1.MetricAggregationFunctionIncrease:
+		aggregate, rollup = "sum", "increase"
+	case v1alpha1.MetricAggregationFunctionSum:
+		aggregate, rollup = "sum", "last_over_time"
+	case v1alpha1.MetricAggregationFunctionAvg:
+		aggregate, rollup = "avg", "avg_over_time"
+	case v1alpha1.MetricAggregationFunctionMax:
+		aggregate, rollup = "max", "max_over_time"
+	}
+
+	labels := []string{"__name__", "resourcemanager_datumapis_com_project_name"}
+	for _, label := range aggregation.By {
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	return fmt.Sprintf("%s by (%s) (%s(%s[%ds]) keep_metric_names)", aggregate, strings.Join(labels, ", "), rollup, selector, int64(metricAggregationWindow(aggregation).Seconds())), nil
+}
+
+// checkMetricAggregation confirms the aggregation can be translated into a
+// metricsql query.
+func checkMetricAggregation(aggregation v1alpha1.MetricAggregation) error {
+	switch aggregation.Function {
+	case v1alpha1.MetricAggregationFunctionIncrease, v1alpha1.MetricAggregationFunctionSum, v1alpha1.MetricAggregationFunctionAvg, v1alpha1.MetricAggregationFunctionMax:
+	default:
+		return fmt.Errorf("aggregation function '%s' is not supported", aggregation.Function)
+	}
+
+	if window := metricAggregationWindow(aggregation); window < 30*time.Second || window > time.Hour || window%time.Second != 0 {
+		return fmt.Errorf("aggregation window must be a whole number of seconds between 30s and 1h")
+	}
+
+	for _, label := range aggregation.By {
+		if !validation.LabelNamePattern.MatchString(label) {
+			return fmt.Errorf("invalid label name '%s'", label)
 		}
+	}
+	return nil
+}
 
-		marshalledQuery := []byte{}
-		marshalledQuery = metricExpr.AppendString(marshalledQuery)
+// metricAggregationWindow returns the rollup window of the aggregation.
+func metricAggregationWindow(aggregation v1alpha1.MetricAggregation) time.Duration {
+	if aggregation.Window.Duration == 0 {
+		return defaultMetricAggregationWindow
+	}
+	return aggregation.Window.Duration
+}
+
+// getMetricAggregationTransforms returns the transforms that convert the
+// results of an aggregation query into metrics. Each sample in the query
+// results is emitted as a log event shaped like a metric, which is then
+// converted into a metric by a log_to_metric transform. The increase of
+// counters is published as an incremental counter so the metrics keep their
+// type, and the aggregates of gauges are published as gauges.
+func getMetricAggregationTransforms(aggregation v1alpha1.MetricAggregation) []chainedTransform {
+	metric := `"kind": "absolute",
+    "gauge": {"value": to_float(result.value[1]) ?? 0.0}`
+	if aggregation.Function == v1alpha1.MetricAggregationFunctionIncrease {
+		metric = `"kind": "incremental",
+    "counter": {"value": to_float(result.value[1]) ?? 0.0}`
+	}
 
-		sources[getVectorComponentID(exportPolicy, projectName, source.Name, vectorSource)] = map[string]any{
-			"type":      "prometheus_scrape",
-			"endpoints": []string{r.MetricsService.Endpoint},
-			"auth": map[string]any{
-				"strategy": "basic",
-				"user":     r.MetricsService.Username,
-				"password": r.MetricsService.Password,
+	return []chainedTransform{
+		{
+			name: "aggregate-results",
+			config: map[string]any{
+				"type": "remap",
+				"source": fmt.Sprintf(`results = array(.data.result) ?? []
+. = map_values(results) -> |result| {
+  tags = object(result.metric) ?? {}
+  name = string(tags.__name__) ?? ""
+  del(tags.__name__)
+  {
+    "name": name,
+    "tags": tags,
+    %s
+  }
+}
+`, metric),
+			},
+		},
+		{
+			name: "aggregate-metrics",
+			config: map[string]any{
+				"type":        "log_to_metric",
+				"all_metrics": true,
+				"metrics":     []any{},
 			},
+		},
+	}
+}
+
+const (
+	// logsScrapeInterval is how often the logs service is queried for new logs.
+	// Each query selects the logs within a window of the same length.
+	logsScrapeInterval = 30 * time.Second
+
+	// logsIngestionDelay is how far the query window trails the current time
+	// so logs that are ingested shortly after they're emitted are included.
+	logsIngestionDelay = time.Minute
+)
+
+// getLogsQuery returns a VRL program that creates the LogsQL query of a log
+// source. The query selects the logs with a timestamp in the half-open window
+// that ended at the last multiple of the scrape interval before the ingestion
+// delay. Vector queries the logs service once per scrape interval, so each
+// query selects the window after the one selected by the previous query
+// instead of overlapping with it or leaving a gap.
+func getLogsQuery(logs v1alpha1.LogSource) string {
+	interval := int64(logsScrapeInterval.Seconds())
+	return fmt.Sprintf(`end = to_unix_timestamp(now()) - %d
+end = end - end %% %d
+"_time:[" + to_string(end - %d) + ", " + to_string(end) + ") (" + %s + ")"`, int64(logsIngestionDelay.Seconds()), interval, interval, vrlString(logs.LogsQL))
+}
+
+// getLogSourceVectorConfig creates a vector configuration that periodically
+// queries the logs service for the logs selected by the source. The logs are
+// restricted to the project using the extra_filters query argument so the
+// source's filter can't select logs from other projects.
+func (r *ExportPolicyReconciler) getLogSourceVectorConfig(projectName string, logs v1alpha1.LogSource) (map[string]any, error) {
+	if r.LogsService.Endpoint == "" {
+		return nil, &sourceConfigurationError{
+			reason: "Unsupported",
+			err:    fmt.Errorf("log sources are not supported because the logs service is not configured"),
+		}
+	}
+
+	sourceConfig := map[string]any{
+		"type":                 "http_client",
+		"endpoint":             r.LogsService.Endpoint,
+		"method":               "GET",
+		"scrape_interval_secs": int64(logsScrapeInterval.Seconds()),
+		"query": map[string]any{
+			// The query is evaluated before every request so the bounds of the
+			// window move with each scrape.
 			"query": map[string]any{
-				"match[]": []string{string(marshalledQuery)},
+				"type":  "vrl",
+				"value": getLogsQuery(logs),
 			},
+			"extra_filters": []string{fmt.Sprintf("resourcemanager_datumapis_com_project_name:=%q", projectName)},
+		},
+		"decoding": map[string]any{
+			"codec": "json",
+		},
+		"framing": map[string]any{
+			"method": "newline_delimited",
+		},
+	}
+	if r.LogsService.Username != "" {
+		sourceConfig["auth"] = map[string]any{
+			"strategy": "basic",
+			"user":     r.LogsService.Username,
+			"password": r.LogsService.Password,
 		}
 	}
 
-	// Configure sinks
-	sinks := vectorConfig["sinks"].(map[string]any)
+	return sourceConfig, nil
+}
 
-	for _, sink := range exportPolicy.Spec.Sinks {
-		sinkConfig, err := getSinkVectorConfig(ctx, client, projectName, sink, exportPolicy)
+// parseMetricSourceQuery parses the metricsql query of a metric source. Only
+// queries that select metrics using label filters are supported.
+func parseMetricSourceQuery(source v1alpha1.TelemetrySource) (*metricsql.MetricExpr, error) {
+	if source.Metrics == nil {
+		return nil, &sourceConfigurationError{
+			reason: "InvalidSource",
+			err:    fmt.Errorf("source does not configure any metrics"),
+		}
+	}
+
+	switch {
+	case source.Metrics.MetricsQL != "" && source.Metrics.Selector != nil:
+		return nil, &sourceConfigurationError{
+			reason: "InvalidSource",
+			err:    fmt.Errorf("only one of metricsql or selector can be configured"),
+		}
+	case source.Metrics.Selector != nil:
+		labelFilters, err := compileMetricSelector(*source.Metrics.Selector)
 		if err != nil {
-			log.FromContext(ctx).Error(err, "failed to get vector configuration for sink", "sink", sink.Name)
-			continue
+			return nil, &sourceConfigurationError{reason: "InvalidSelector", err: err}
 		}
 
-		sinks[getVectorComponentID(exportPolicy, projectName, sink.Name, vectorSink)] = sinkConfig
+		metricExpr := &metricsql.MetricExpr{}
+		if len(labelFilters) > 0 {
+			metricExpr.LabelFilterss = [][]metricsql.LabelFilter{labelFilters}
+		}
+		return metricExpr, nil
 	}
 
-	return vectorConfig
+	query, err := metricsql.Parse(source.Metrics.MetricsQL)
+	if err != nil {
+		return nil, &sourceConfigurationError{
+			reason: "InvalidQuery",
+			err:    fmt.Errorf("unable to parse metricsql query: %w", err),
+		}
+	}
+
+	metricExpr, ok := query.(*metricsql.MetricExpr)
+	if !ok {
+		return nil, &sourceConfigurationError{
+			reason: "UnsupportedExpression",
+			err:    fmt.Errorf(`only metrics queries in the format '{label="value"}' are supported`),
+		}
+	}
+
+	return metricExpr, nil
+}
+
+// compileMetricSelector compiles the metric selector into the label filters of
+// a metricsql query.
+func compileMetricSelector(selector v1alpha1.MetricSelector) ([]metricsql.LabelFilter, error) {
+	labelFilters := []metricsql.LabelFilter{}
+	if selector.ServiceName != "" {
+		labelFilters = append(labelFilters, metricsql.LabelFilter{Label: "service_name", Value: selector.ServiceName})
+	}
+
+	for label, values := range map[string][]string{
+		"resource_kind":      selector.ResourceKinds,
+		"resource_name":      selector.ResourceNames,
+		"resource_namespace": selector.ResourceNamespaces,
+	} {
+		if len(values) > 0 {
+			labelFilters = append(labelFilters, metricsql.LabelFilter{Label: label, Value: anyOfRegex(values), IsRegexp: true})
+		}
+	}
+
+	if len(selector.MetricNames) > 0 {
+		patterns := []string{}
+		for _, glob := range selector.MetricNames {
+			patterns = append(patterns, globRegex(glob))
+		}
+		labelFilters = append(labelFilters, metricsql.LabelFilter{Label: "__name__", Value: strings.Join(patterns, "|"), IsRegexp: true})
+	}
+
+	for _, matcher := range selector.LabelMatchers {
+		labelFilter := metricsql.LabelFilter{Label: matcher.Label, Value: matcher.Value}
+		switch matcher.Operator {
+		case v1alpha1.LabelMatchOperatorEqual, "":
+		case v1alpha1.LabelMatchOperatorNotEqual:
+			labelFilter.IsNegative = true
+		case v1alpha1.LabelMatchOperatorRegexMatch, v1alpha1.LabelMatchOperatorNotRegexMatch:
+			if _, err := regexp.Compile(matcher.Value); err != nil {
+				return nil, fmt.Errorf("invalid regular expression for label '%s': %w", matcher.Label, err)
+			}
+			labelFilter.IsRegexp = true
+			labelFilter.IsNegative = matcher.Operator == v1alpha1.LabelMatchOperatorNotRegexMatch
+		default:
+			return nil, fmt.Errorf("label match operator '%s' is not supported", matcher.Operator)
+		}
+		labelFilters = append(labelFilters, labelFilter)
+	}
+
+	// Sort the label filters so the compiled query is stable and the vector
+	// configuration doesn't change between reconciles.
+	slices.SortStableFunc(labelFilters, func(a, b metricsql.LabelFilter) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return labelFilters, nil
+}
+
+// anyOfRegex returns a regular expression that matches any of the values.
+func anyOfRegex(values []string) string {
+	escaped := []string{}
+	for _, value := range values {
+		escaped = append(escaped, regexp.QuoteMeta(value))
+	}
+	return strings.Join(escaped, "|")
+}
+
+// globRegex converts a glob pattern into a regular expression.
+func globRegex(glob string) string {
+	var pattern strings.Builder
+	for _, char := range glob {
+		switch char {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	return pattern.String()
 }
 
 const (
-	vectorSource = "source"
-	vectorSink   = "sink"
+	vectorSource    = "source"
+	vectorSink      = "sink"
+	vectorTransform = "transform"
 )
 
 // getVectorComponentID will return the fully qualified ID of a source for an export
@@ -116,32 +623,184 @@ func getVectorComponentID(exportPolicy *v1alpha1.ExportPolicy, projectName, comp
 	return fmt.Sprintf("export-policy:%s:%s:%s:%s:%s-%s", projectName, exportPolicy.Namespace, exportPolicy.Name, exportPolicy.UID, componentName, componentType)
 }
 
-// getSinkVectorConfig creates a vector configuration for the given sink.
-func getSinkVectorConfig(ctx context.Context, client client.Client, projectName string, sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+// getSinkVectorConfig creates a vector configuration for the given sink. Only
+// the sources that have been added to the vector configuration are used as
+// inputs for the sink since vector will reject any configuration that
+// references components that don't exist. The source outputs map the name of
+// each configured source to the ID of the component that outputs its telemetry.
+//
+// The sink's transforms are returned keyed by their component IDs. The
+// transforms are chained together between the sources and the sink.
+func getSinkVectorConfig(ctx context.Context, client client.Client, projectName string, sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy, sourceOutputs map[string]string) (map[string]any, map[string]any, error) {
 	config := map[string]any{}
 
+	if err := checkSinkSourceSignals(sink, exportPolicy); err != nil {
+		return nil, nil, err
+	}
+
 	// Get all of the sources that are configured for the sink and add them
-	// to the inputs for the prometheus remote write sink.
+	// to the inputs for the sink.
 	inputs := []string{}
 	for _, source := range sink.Sources {
-		inputs = append(inputs, getVectorComponentID(exportPolicy, projectName, source, vectorSource))
+		output, ok := sourceOutputs[source]
+		if !ok {
+			log.FromContext(ctx).Info("skipping sink input for source that is not configured", "sink", sink.Name, "source", source)
+			continue
+		}
+		inputs = append(inputs, output)
+	}
+	if len(inputs) == 0 {
+		return nil, nil, fmt.Errorf("sink does not have any configured sources")
+	}
+
+	// Insert the sink's transforms between the sources and the sink. Each
+	// transform uses the previous transform as its input.
+	transforms, err := getSinkTransforms(sink, exportPolicy)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	transformConfigs, inputs := chainTransforms(exportPolicy, projectName, sink.Name, inputs, transforms)
 	config["inputs"] = inputs
 
-	// Create the vector configuration for the sink
-	if sink.Target.PrometheusRemoteWrite != nil {
-		prometheusRemoteWriteConfig, err := getPrometheusRemoteWriteSinkVectorConfig(ctx, client, *sink.Target.PrometheusRemoteWrite, exportPolicy)
+	// Create the vector configuration for the sink's target and merge it with
+	// the config.
+	targetConfig, err := getSinkTargetVectorConfig(ctx, client, sink, exportPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	maps.Copy(config, targetConfig)
+
+	if sink.Buffer != nil {
+		bufferConfig, err := getBufferVectorConfig(*sink.Buffer)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if err := checkDiskBufferBudget(sink, exportPolicy); err != nil {
+			return nil, nil, err
 		}
+		config["buffer"] = bufferConfig
+	}
 
-		// Merge the prometheus remote write config with the config
-		maps.Copy(config, prometheusRemoteWriteConfig)
-	} else {
-		return nil, fmt.Errorf("sink %s is not a valid sink", sink.Name)
+	// Every trace source selects spans from the same platform trace receiver,
+	// so a sink that blocks would apply backpressure to the spans of every
+	// project. Sinks that publish traces drop new spans when their buffer is
+	// full instead.
+	if sinkSignal(sink, exportPolicy) == validation.SignalTraces {
+		bufferConfig, _ := config["buffer"].(map[string]any)
+		if bufferConfig == nil {
+			bufferConfig = map[string]any{"type": "memory"}
+		}
+		bufferConfig["when_full"] = "drop_newest"
+		config["buffer"] = bufferConfig
 	}
 
-	return config, nil
+	if sink.Acknowledgements {
+		config["acknowledgements"] = map[string]any{
+			"enabled": true,
+		}
+	}
+
+	return config, transformConfigs, nil
+}
+
+// checkSinkSourceSignals confirms the sources of the sink produce telemetry
+// signals that can be published to the sink's target. Vector rejects
+// configurations that connect components with incompatible data types. A sink
+// can only publish a single signal since the encoding of the sink depends on
+// the signal.
+func checkSinkSourceSignals(sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) error {
+	if sink.Target == nil {
+		return nil
+	}
+
+	var incompatibleSources, signals []string
+	for _, source := range exportPolicy.Spec.Sources {
+		if !slices.Contains(sink.Sources, source.Name) {
+			continue
+		}
+
+		signal := validation.SourceSignal(source)
+		if signal == "" {
+			continue
+		}
+		if !slices.Contains(validation.SinkTargetSignals(*sink.Target), signal) {
+			incompatibleSources = append(incompatibleSources, source.Name)
+		}
+		if !slices.Contains(signals, signal) {
+			signals = append(signals, signal)
+		}
+	}
+
+	if len(incompatibleSources) > 0 {
+		return &sinkConfigurationError{
+			reason: "IncompatibleSource",
+			err:    fmt.Errorf("sink target does not support the telemetry produced by sources: %s", strings.Join(incompatibleSources, ", ")),
+		}
+	} else if len(signals) > 1 {
+		return &sinkConfigurationError{
+			reason: "IncompatibleSource",
+			err:    fmt.Errorf("sink can only publish one telemetry signal, found: %s", strings.Join(signals, ", ")),
+		}
+	}
+
+	return nil
+}
+
+// sinkSignal returns the telemetry signal published by the sink. Sinks publish
+// metrics unless their sources produce another signal.
+func sinkSignal(sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) string {
+	for _, source := range exportPolicy.Spec.Sources {
+		if slices.Contains(sink.Sources, source.Name) {
+			if signal := validation.SourceSignal(source); signal != "" {
+				return signal
+			}
+		}
+	}
+	return validation.SignalMetrics
+}
+
+// sinkConfigurationError describes why the configuration of a sink could not
+// be translated into a vector configuration. The reason is used as the reason
+// of the sink's Accepted condition.
+type sinkConfigurationError struct {
+	reason string
+	err    error
+}
+
+func (e *sinkConfigurationError) Error() string {
+	return e.err.Error()
+}
+
+// getSinkTargetVectorConfig creates the vector configuration for the target of
+// the given sink. Errors that are caused by the sink's configuration are
+// returned as a sinkConfigurationError.
+func getSinkTargetVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	switch {
+	case sink.Target == nil:
+		// Fall through to the invalid target error below.
+	case sink.Target.PrometheusRemoteWrite != nil:
+		return getPrometheusRemoteWriteSinkVectorConfig(ctx, client, *sink.Target.PrometheusRemoteWrite, exportPolicy)
+	case sink.Target.OpenTelemetry != nil && sink.Target.OpenTelemetry.HTTP != nil:
+		return getOpenTelemetryHTTPSinkVectorConfig(ctx, client, *sink.Target.OpenTelemetry.HTTP, sinkSignal(sink, exportPolicy), exportPolicy)
+	case sink.Target.DatadogMetrics != nil:
+		return getDatadogMetricsSinkVectorConfig(ctx, client, *sink.Target.DatadogMetrics, exportPolicy)
+	case sink.Target.Loki != nil:
+		return getLokiSinkVectorConfig(ctx, client, *sink.Target.Loki, exportPolicy)
+	case sink.Target.ObjectStorage != nil:
+		return getObjectStorageSinkVectorConfig(ctx, client, *sink.Target.ObjectStorage, sinkSignal(sink, exportPolicy), exportPolicy)
+	case sink.Target.Kafka != nil:
+		return getKafkaSinkVectorConfig(ctx, client, *sink.Target.Kafka, sinkSignal(sink, exportPolicy), exportPolicy)
+	case sink.Target.SplunkHEC != nil:
+		return getSplunkHECSinkVectorConfig(ctx, client, *sink.Target.SplunkHEC, exportPolicy)
+	case sink.Target.Elasticsearch != nil:
+		return getElasticsearchSinkVectorConfig(ctx, client, *sink.Target.Elasticsearch, exportPolicy)
+	}
+
+	return nil, &sinkConfigurationError{
+		reason: "InvalidTarget",
+		err:    fmt.Errorf("sink '%s' does not configure a supported target", sink.Name),
+	}
 }
 
 // getPrometheusRemoteWriteSinkVectorConfig creates a vector configuration for
@@ -154,43 +813,946 @@ func getPrometheusRemoteWriteSinkVectorConfig(ctx context.Context, client client
 	}
 
 	if sink.Authentication != nil {
-		secret, err := retrieveBasicAuthSecret(ctx, client, sink.Authentication.BasicAuth.SecretRef, exportPolicy)
+		authConfig, err := getAuthenticationVectorConfig(ctx, client, *sink.Authentication, exportPolicy)
 		if err != nil {
 			return nil, err
 		}
+		sinkConfig["auth"] = authConfig
 
-		sinkConfig["auth"] = map[string]any{
-			"strategy": "basic",
-			"user":     string(secret.Data["username"]),
-			"password": string(secret.Data["password"]),
+		// The region is used by vector when signing requests.
+		if sink.Authentication.AWSSigV4 != nil {
+			sinkConfig["aws"] = map[string]any{
+				"region": sink.Authentication.AWSSigV4.Region,
+			}
+		}
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["batch"] = batchConfig
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sink.Headers) > 0 {
+		headers, err := getHeadersVectorConfig(ctx, client, sink.Headers, exportPolicy)
+		if err != nil {
+			return nil, err
 		}
+		requestConfig["headers"] = headers
 	}
+	sinkConfig["request"] = requestConfig
 
 	return sinkConfig, nil
 }
 
-// retrieveBasicAuthSecret retrieves the basic auth secret for the prometheus.
-// This will return an error if the secret does not exist, is not of the
-// correct type, or if the secret data does not contain the expected keys.
-func retrieveBasicAuthSecret(ctx context.Context, client client.Client, secretRef v1alpha1.LocalSecretReference, exportPolicy *v1alpha1.ExportPolicy) (*corev1.Secret, error) {
-	secret := &corev1.Secret{}
-	err := client.Get(ctx, types.NamespacedName{
-		Name:      secretRef.Name,
-		Namespace: exportPolicy.Namespace,
-	}, secret)
+// The protobuf descriptor set that vector uses to encode OTLP requests. The
+// descriptor set is generated by `make otlp-descriptors` and mounted into the
+// vector deployment from the vector-otlp-descriptors config map.
+const (
+	otlpProtobufDescriptorFile = "/etc/vector-otlp/otlp.desc"
+	otlpMetricsMessageType     = "opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceRequest"
+	otlpTracesMessageType      = "opentelemetry.proto.collector.trace.v1.ExportTraceServiceRequest"
+)
 
-	if errors.IsNotFound(err) {
-		return nil, fmt.Errorf("secret '%s' not found", secretRef.Name)
-	} else if err != nil {
-		log.FromContext(ctx).Error(err, "failed to get secret", "secret", secretRef.Name)
-		return nil, fmt.Errorf("internal error when retrieving secret")
-	} else if secret.Type != "kubernetes.io/basic-auth" {
-		return nil, fmt.Errorf("secret '%s' is not of type kubernetes.io/basic-auth", secretRef.Name)
-	} else if _, ok := secret.Data["username"]; !ok {
-		return nil, fmt.Errorf("secret '%s' does not contain a username", secretRef.Name)
-	} else if _, ok := secret.Data["password"]; !ok {
-		return nil, fmt.Errorf("secret '%s' does not contain a password", secretRef.Name)
+// getOpenTelemetryHTTPSinkVectorConfig creates a vector configuration for the
+// OpenTelemetry sink using the OTLP HTTP protocol.
+func getOpenTelemetryHTTPSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.OpenTelemetryHTTPSink, signal string, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	messageType := otlpMetricsMessageType
+	if signal == validation.SignalTraces {
+		messageType = otlpTracesMessageType
 	}
 
-	return secret, nil
+	protocolConfig := map[string]any{
+		"type":   "http",
+		"uri":    sink.Endpoint,
+		"method": "post",
+	}
+
+	// Each event is encoded as an export request. The requests of a batch are
+	// concatenated without any framing, which protobuf decodes as a single
+	// request containing the resources of every request in the batch.
+	headers := map[string]string{}
+	switch sink.Encoding {
+	case v1alpha1.OpenTelemetryEncodingProtobuf, "":
+		protocolConfig["encoding"] = map[string]any{
+			"codec": "protobuf",
+			"protobuf": map[string]any{
+				"desc_file":    otlpProtobufDescriptorFile,
+				"message_type": messageType,
+			},
+		}
+		protocolConfig["framing"] = map[string]any{
+			"method": "bytes",
+		}
+		headers["content-type"] = "application/x-protobuf"
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidEncoding",
+			err:    fmt.Errorf("encoding '%s' is not supported", sink.Encoding),
+		}
+	}
+
+	configuredHeaders, err := getHeadersVectorConfig(ctx, client, sink.Headers, exportPolicy)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(headers, configuredHeaders)
+
+	if sink.Authentication != nil {
+		if sink.Authentication.AWSSigV4 != nil {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("AWS SigV4 authentication is only supported by Prometheus Remote Write sinks"),
+			}
+		}
+
+		authConfig, err := getAuthenticationVectorConfig(ctx, client, *sink.Authentication, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		protocolConfig["auth"] = authConfig
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		protocolConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	protocolConfig["batch"] = batchConfig
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+	requestConfig["headers"] = headers
+	protocolConfig["request"] = requestConfig
+
+	return map[string]any{
+		"type":     "opentelemetry",
+		"protocol": protocolConfig,
+	}, nil
+}
+
+// datadogSites maps the Datadog sites that can be configured on a sink to the
+// site domain that's used by vector.
+var datadogSites = map[v1alpha1.DatadogSite]string{
+	v1alpha1.DatadogSiteUS1:    "datadoghq.com",
+	v1alpha1.DatadogSiteUS3:    "us3.datadoghq.com",
+	v1alpha1.DatadogSiteUS5:    "us5.datadoghq.com",
+	v1alpha1.DatadogSiteEU1:    "datadoghq.eu",
+	v1alpha1.DatadogSiteAP1:    "ap1.datadoghq.com",
+	v1alpha1.DatadogSiteUS1FED: "ddog-gov.com",
+}
+
+// getDatadogMetricsSinkVectorConfig creates a vector configuration for the
+// Datadog metrics sink.
+func getDatadogMetricsSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.DatadogMetricsSink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	site := v1alpha1.DatadogSiteUS1
+	if sink.Site != "" {
+		site = sink.Site
+	}
+
+	siteDomain, ok := datadogSites[site]
+	if !ok {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidSite",
+			err:    fmt.Errorf("datadog site '%s' is not supported", site),
+		}
+	}
+
+	apiKey, err := retrieveSecretKey(ctx, client, sink.APIKeySecretRef, exportPolicy)
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	retryConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"type":            "datadog_metrics",
+		"default_api_key": string(apiKey),
+		"site":            siteDomain,
+		"batch":           batchConfig,
+		"request":         retryConfig,
+	}, nil
+}
+
+// getLokiSinkVectorConfig creates a vector configuration for the loki sink.
+func getLokiSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.LokiSink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	labels := map[string]string{}
+	for _, label := range sink.Labels {
+		labels[label.Name] = label.Value
+	}
+
+	sinkConfig := map[string]any{
+		"type":     "loki",
+		"endpoint": sink.Endpoint,
+		"labels":   labels,
+		"encoding": map[string]any{
+			"codec": "json",
+		},
+	}
+
+	if sink.TenantID != "" {
+		sinkConfig["tenant_id"] = sink.TenantID
+	}
+
+	if sink.Authentication != nil {
+		if sink.Authentication.AWSSigV4 != nil {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("AWS SigV4 authentication is only supported by Prometheus Remote Write sinks"),
+			}
+		}
+
+		authConfig, err := getAuthenticationVectorConfig(ctx, client, *sink.Authentication, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["auth"] = authConfig
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["batch"] = batchConfig
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := getHeadersVectorConfig(ctx, client, sink.Headers, exportPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		requestConfig["headers"] = headers
+	}
+	sinkConfig["request"] = requestConfig
+
+	return sinkConfig, nil
+}
+
+// getObjectStorageSinkVectorConfig creates a vector configuration for the
+// object storage sink. Objects are written as newline delimited JSON.
+func getObjectStorageSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.ObjectStorageSink, signal string, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	// Re-checked here for policies that were stored before the webhook required
+	// metric labels to be referenced as tags. Vector drops every event whose
+	// key prefix can't be rendered.
+	if signal == validation.SignalMetrics {
+		if err := validation.CheckMetricTemplate(sink.KeyPrefix); err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidTemplate", err: err}
+		}
+	}
+
+	var compression string
+	switch sink.Compression {
+	case v1alpha1.ObjectStorageCompressionGzip, "":
+		compression = "gzip"
+	case v1alpha1.ObjectStorageCompressionZstd:
+		compression = "zstd"
+	case v1alpha1.ObjectStorageCompressionNone:
+		compression = "none"
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidCompression",
+			err:    fmt.Errorf("compression '%s' is not supported", sink.Compression),
+		}
+	}
+
+	secret, err := retrieveAWSCredentialsSecret(ctx, client, sink.SecretRef, exportPolicy)
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+	}
+
+	sinkConfig := map[string]any{
+		"type":        "aws_s3",
+		"bucket":      sink.Bucket,
+		"region":      sink.Region,
+		"compression": compression,
+		"auth": map[string]any{
+			"access_key_id":     string(secret.Data["access-key-id"]),
+			"secret_access_key": string(secret.Data["secret-access-key"]),
+		},
+		"encoding": map[string]any{
+			"codec": "json",
+		},
+		"framing": map[string]any{
+			"method": "newline_delimited",
+		},
+	}
+
+	if sink.KeyPrefix != "" {
+		sinkConfig["key_prefix"] = sink.KeyPrefix
+	}
+
+	if sink.Endpoint != "" {
+		sinkConfig["endpoint"] = sink.Endpoint
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["batch"] = batchConfig
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["request"] = requestConfig
+
+	return sinkConfig, nil
+}
+
+// getKafkaSinkVectorConfig creates a vector configuration for the kafka sink.
+// The protobuf encoding uses vector's native event schema so no schema has to
+// be provided.
+func getKafkaSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.KafkaSink, signal string, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	// Re-checked here for policies that were stored before the webhook required
+	// metric labels to be referenced as tags. Vector drops every event whose
+	// topic can't be rendered.
+	if signal == validation.SignalMetrics {
+		if err := validation.CheckMetricTemplate(sink.Topic); err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidTemplate", err: err}
+		}
+	}
+
+	var codec string
+	switch sink.Encoding {
+	case v1alpha1.KafkaEncodingJSON, "":
+		codec = "json"
+	case v1alpha1.KafkaEncodingProtobuf:
+		codec = "native"
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidEncoding",
+			err:    fmt.Errorf("encoding '%s' is not supported", sink.Encoding),
+		}
+	}
+
+	sinkConfig := map[string]any{
+		"type":              "kafka",
+		"bootstrap_servers": strings.Join(sink.Brokers, ","),
+		"topic":             sink.Topic,
+		"encoding": map[string]any{
+			"codec": codec,
+		},
+	}
+
+	if sink.SASL != nil {
+		mechanism := sink.SASL.Mechanism
+		if mechanism == "" {
+			mechanism = v1alpha1.KafkaSASLMechanismScramSHA512
+		} else if mechanism != v1alpha1.KafkaSASLMechanismScramSHA256 && mechanism != v1alpha1.KafkaSASLMechanismScramSHA512 {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("SASL mechanism '%s' is not supported", mechanism),
+			}
+		}
+
+		secret, err := retrieveBasicAuthSecret(ctx, client, sink.SASL.SecretRef, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+		}
+
+		sinkConfig["sasl"] = map[string]any{
+			"enabled":   true,
+			"mechanism": string(mechanism),
+			"username":  string(secret.Data["username"]),
+			"password":  string(secret.Data["password"]),
+		}
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig["enabled"] = true
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	return sinkConfig, nil
+}
+
+// getSplunkHECSinkVectorConfig creates a vector configuration for the splunk
+// hec sink. Logs are published as JSON encoded events.
+func getSplunkHECSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.SplunkHECSink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	token, err := retrieveSecretKey(ctx, client, sink.TokenSecretKeyRef, exportPolicy)
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+	} else if len(token) == 0 {
+		return nil, &sinkConfigurationError{
+			reason: "InvalidAuthentication",
+			err:    fmt.Errorf("secret '%s' contains an empty HEC token", sink.TokenSecretKeyRef.Name),
+		}
+	}
+
+	sinkConfig := map[string]any{
+		"type":          "splunk_hec_logs",
+		"endpoint":      sink.Endpoint,
+		"default_token": string(token),
+		"encoding": map[string]any{
+			"codec": "json",
+		},
+	}
+
+	if sink.Index != "" {
+		sinkConfig["index"] = sink.Index
+	}
+
+	if sink.SourceType != "" {
+		sinkConfig["sourcetype"] = sink.SourceType
+	}
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["batch"] = batchConfig
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["request"] = requestConfig
+
+	return sinkConfig, nil
+}
+
+// getElasticsearchSinkVectorConfig creates a vector configuration for the
+// elasticsearch sink. Logs are indexed with the bulk API unless a data stream
+// is configured.
+func getElasticsearchSinkVectorConfig(ctx context.Context, client client.Client, sink v1alpha1.ElasticsearchSink, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	sinkConfig := map[string]any{
+		"type":      "elasticsearch",
+		"endpoints": sink.Endpoints,
+	}
+
+	if sink.DataStream != nil {
+		if sink.Index != "" {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidIndex",
+				err:    fmt.Errorf("an index can not be configured when publishing to a data stream"),
+			}
+		}
+
+		dataStream := map[string]any{}
+		if sink.DataStream.Type != "" {
+			dataStream["type"] = sink.DataStream.Type
+		}
+		if sink.DataStream.Dataset != "" {
+			dataStream["dataset"] = sink.DataStream.Dataset
+		}
+		if sink.DataStream.Namespace != "" {
+			dataStream["namespace"] = sink.DataStream.Namespace
+		}
+		sinkConfig["mode"] = "data_stream"
+		sinkConfig["data_stream"] = dataStream
+	} else {
+		index := sink.Index
+		if index == "" {
+			index = "telemetry-%Y.%m.%d"
+		}
+		sinkConfig["mode"] = "bulk"
+		sinkConfig["bulk"] = map[string]any{
+			"index": index,
+		}
+	}
+
+	requestConfig, err := getRetryVectorConfig(sink.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth := sink.Authentication; auth != nil {
+		switch {
+		case auth.BasicAuth != nil && auth.APIKey != nil:
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("only one authentication method can be configured"),
+			}
+		case auth.BasicAuth != nil:
+			authConfig, err := getAuthenticationVectorConfig(ctx, client, v1alpha1.Authentication{BasicAuth: auth.BasicAuth}, exportPolicy)
+			if err != nil {
+				return nil, err
+			}
+			sinkConfig["auth"] = authConfig
+		case auth.APIKey != nil:
+			// Vector doesn't support API key authentication for the
+			// elasticsearch sink so the key is added to the authorization
+			// header of every request.
+			apiKey, err := retrieveSecretKey(ctx, client, auth.APIKey.SecretKeyRef, exportPolicy)
+			if err != nil {
+				return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+			} else if len(apiKey) == 0 {
+				return nil, &sinkConfigurationError{
+					reason: "InvalidAuthentication",
+					err:    fmt.Errorf("secret '%s' contains an empty API key", auth.APIKey.SecretKeyRef.Name),
+				}
+			}
+			requestConfig["headers"] = map[string]string{
+				"Authorization": "ApiKey " + string(apiKey),
+			}
+		default:
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("an authentication method must be configured"),
+			}
+		}
+	}
+	sinkConfig["request"] = requestConfig
+
+	if sink.TLS != nil {
+		tlsConfig, err := getTLSVectorConfig(ctx, client, *sink.TLS, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+		sinkConfig["tls"] = tlsConfig
+	}
+
+	batchConfig, err := getBatchVectorConfig(sink.Batch)
+	if err != nil {
+		return nil, err
+	}
+	sinkConfig["batch"] = batchConfig
+
+	return sinkConfig, nil
+}
+
+// getTLSVectorConfig creates the vector tls configuration for a sink. The
+// certificates and keys are provided to vector inline in the PEM format.
+func getTLSVectorConfig(ctx context.Context, client client.Client, tls v1alpha1.TLSConfig, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	tlsConfig := map[string]any{}
+
+	if tls.CABundle != nil {
+		var caBundle []byte
+		var err error
+		switch {
+		case tls.CABundle.SecretKeyRef != nil:
+			caBundle, err = retrieveSecretKey(ctx, client, *tls.CABundle.SecretKeyRef, exportPolicy)
+		case tls.CABundle.ConfigMapKeyRef != nil:
+			caBundle, err = retrieveConfigMapKey(ctx, client, *tls.CABundle.ConfigMapKeyRef, exportPolicy)
+		default:
+			err = fmt.Errorf("a source for the CA bundle must be configured")
+		}
+
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidTLS", err: err}
+		} else if block, _ := pem.Decode(caBundle); block == nil {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidTLS",
+				err:    fmt.Errorf("the CA bundle does not contain a PEM encoded certificate"),
+			}
+		}
+		tlsConfig["ca_file"] = string(caBundle)
+	}
+
+	if tls.ClientCertificate != nil {
+		secret, err := retrieveTLSSecret(ctx, client, *tls.ClientCertificate, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidTLS", err: err}
+		}
+		tlsConfig["crt_file"] = string(secret.Data[corev1.TLSCertKey])
+		tlsConfig["key_file"] = string(secret.Data[corev1.TLSPrivateKeyKey])
+	}
+
+	if tls.ServerName != "" {
+		tlsConfig["server_name"] = tls.ServerName
+	}
+
+	return tlsConfig, nil
+}
+
+// getHeadersVectorConfig creates the headers that vector will add to requests
+// sent by a sink. Header values are retrieved from secrets when configured.
+func getHeadersVectorConfig(ctx context.Context, client client.Client, headers []v1alpha1.HTTPHeader, exportPolicy *v1alpha1.ExportPolicy) (map[string]string, error) {
+	headersConfig := map[string]string{}
+	for _, header := range headers {
+		if header.SecretKeyRef == nil {
+			headersConfig[header.Name] = header.Value
+			continue
+		}
+
+		value, err := retrieveSecretKey(ctx, client, *header.SecretKeyRef, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidHeader",
+				err:    fmt.Errorf("failed to retrieve value of header '%s': %w", header.Name, err),
+			}
+		}
+		headersConfig[header.Name] = string(value)
+	}
+
+	return headersConfig, nil
+}
+
+// getAuthenticationVectorConfig creates the vector auth configuration for a
+// sink using the authentication options configured on the sink.
+func getAuthenticationVectorConfig(ctx context.Context, client client.Client, auth v1alpha1.Authentication, exportPolicy *v1alpha1.ExportPolicy) (map[string]any, error) {
+	switch {
+	case auth.BasicAuth != nil:
+		secret, err := retrieveBasicAuthSecret(ctx, client, auth.BasicAuth.SecretRef, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+		}
+
+		return map[string]any{
+			"strategy": "basic",
+			"user":     string(secret.Data["username"]),
+			"password": string(secret.Data["password"]),
+		}, nil
+	case auth.BearerToken != nil:
+		token, err := retrieveSecretKey(ctx, client, auth.BearerToken.SecretKeyRef, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+		} else if len(token) == 0 {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("secret '%s' contains an empty bearer token", auth.BearerToken.SecretKeyRef.Name),
+			}
+		}
+
+		return map[string]any{
+			"strategy": "bearer",
+			"token":    string(token),
+		}, nil
+	case auth.OAuth2 != nil:
+		token, err := retrieveOAuth2Token(ctx, client, *auth.OAuth2, exportPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"strategy": "bearer",
+			"token":    token.AccessToken,
+		}, nil
+	case auth.AWSSigV4 != nil:
+		// Vector always signs remote write requests for the Amazon Managed
+		// Service for Prometheus service.
+		if auth.AWSSigV4.Service != "" && auth.AWSSigV4.Service != "aps" {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidAuthentication",
+				err:    fmt.Errorf("AWS service '%s' is not supported", auth.AWSSigV4.Service),
+			}
+		}
+
+		secret, err := retrieveAWSCredentialsSecret(ctx, client, auth.AWSSigV4.SecretRef, exportPolicy)
+		if err != nil {
+			return nil, &sinkConfigurationError{reason: "InvalidAuthentication", err: err}
+		}
+
+		authConfig := map[string]any{
+			"strategy":          "aws",
+			"access_key_id":     string(secret.Data["access-key-id"]),
+			"secret_access_key": string(secret.Data["secret-access-key"]),
+			"region":            auth.AWSSigV4.Region,
+		}
+		if auth.AWSSigV4.AssumeRoleARN != "" {
+			authConfig["assume_role"] = auth.AWSSigV4.AssumeRoleARN
+		}
+		return authConfig, nil
+	}
+
+	return nil, &sinkConfigurationError{
+		reason: "InvalidAuthentication",
+		err:    fmt.Errorf("an authentication method must be configured"),
+	}
+}
+
+// getBatchVectorConfig translates the batch configuration of a sink into the
+// vector batch options. An error is returned if the batch configuration can
+// not be represented in the vector configuration.
+func getBatchVectorConfig(batch v1alpha1.Batch) (map[string]any, error) {
+	var err error
+	if batch.Timeout.Duration <= 0 {
+		err = fmt.Errorf("batch timeout must be greater than zero, got '%s'", batch.Timeout.Duration)
+	} else if batch.MaxSize < 1 {
+		err = fmt.Errorf("batch max size must be at least 1, got %d", batch.MaxSize)
+	}
+
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidBatch", err: err}
+	}
+
+	return map[string]any{
+		"timeout_secs": batch.Timeout.Seconds(),
+		"max_events":   batch.MaxSize,
+	}, nil
+}
+
+// vectorMinDiskBufferSize is the minimum size of a disk buffer that's supported
+// by vector. It's slightly larger than 256Mi, so disk buffers are increased to
+// the minimum size supported by vector.
+const vectorMinDiskBufferSize = 268435488
+
+// getBufferVectorConfig translates the buffer configuration of a sink into the
+// vector buffer options. An error is returned if the buffer configuration can
+// not be represented in the vector configuration.
+func getBufferVectorConfig(buffer v1alpha1.SinkBuffer) (map[string]any, error) {
+	config := map[string]any{}
+
+	switch buffer.WhenFull {
+	case v1alpha1.SinkBufferWhenFullBlock, "":
+		config["when_full"] = "block"
+	case v1alpha1.SinkBufferWhenFullDropNewest:
+		config["when_full"] = "drop_newest"
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidBuffer",
+			err:    fmt.Errorf("buffer when full behavior '%s' is not supported", buffer.WhenFull),
+		}
+	}
+
+	switch buffer.Type {
+	case v1alpha1.SinkBufferTypeMemory, "":
+		config["type"] = "memory"
+		if buffer.MaxEvents != 0 {
+			config["max_events"] = buffer.MaxEvents
+		}
+	case v1alpha1.SinkBufferTypeDisk:
+		if buffer.MaxSize == nil || buffer.MaxSize.Cmp(validation.MinDiskBufferSize) < 0 {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidBuffer",
+				err:    fmt.Errorf("disk buffers must have a max size of at least %s", validation.MinDiskBufferSize.String()),
+			}
+		} else if buffer.MaxSize.Cmp(validation.MaxDiskBufferSize) > 0 {
+			return nil, &sinkConfigurationError{
+				reason: "InvalidBuffer",
+				err:    fmt.Errorf("disk buffers can not have a max size larger than %s", validation.MaxDiskBufferSize.String()),
+			}
+		}
+		config["type"] = "disk"
+		config["max_size"] = max(buffer.MaxSize.Value(), vectorMinDiskBufferSize)
+	default:
+		return nil, &sinkConfigurationError{
+			reason: "InvalidBuffer",
+			err:    fmt.Errorf("buffer type '%s' is not supported", buffer.Type),
+		}
+	}
+
+	return config, nil
+}
+
+// checkDiskBufferBudget confirms the sink's disk buffer fits in the disk buffer
+// budget of the export policy together with the disk buffers of the sinks that
+// are defined before it.
+func checkDiskBufferBudget(sink v1alpha1.TelemetrySink, exportPolicy *v1alpha1.ExportPolicy) error {
+	if validation.DiskBufferSize(sink) == 0 {
+		return nil
+	}
+
+	var total int64
+	for _, policySink := range exportPolicy.Spec.Sinks {
+		total += validation.DiskBufferSize(policySink)
+		if policySink.Name == sink.Name {
+			break
+		}
+	}
+
+	if total > validation.MaxExportPolicyDiskBufferSize.Value() {
+		return &sinkConfigurationError{
+			reason: "InvalidBuffer",
+			err:    fmt.Errorf("the disk buffers of the export policy's sinks can not be larger than %s in total", validation.MaxExportPolicyDiskBufferSize.String()),
+		}
+	}
+	return nil
+}
+
+// getRetryVectorConfig translates the retry configuration of a sink into the
+// vector request options. An error is returned if the retry configuration can
+// not be represented in the vector configuration.
+func getRetryVectorConfig(retry v1alpha1.Retry) (map[string]any, error) {
+	var err error
+	backoff := retry.BackoffDuration.Duration
+	if retry.MaxAttempts < 1 {
+		err = fmt.Errorf("retry max attempts must be at least 1, got %d", retry.MaxAttempts)
+	} else if backoff < time.Second || backoff%time.Second != 0 {
+		// Vector only supports configuring the backoff in whole seconds.
+		err = fmt.Errorf("retry backoff duration must be a whole number of seconds, got '%s'", backoff)
+	}
+
+	if err != nil {
+		return nil, &sinkConfigurationError{reason: "InvalidRetry", err: err}
+	}
+
+	backoffSeconds := int64(backoff / time.Second)
+
+	return map[string]any{
+		// Vector doesn't count the initial request as a retry attempt.
+		"retry_attempts": retry.MaxAttempts - 1,
+		// Vector will use a fibonacci backoff between the initial and max
+		// durations. Setting both to the same value results in a constant
+		// backoff between each attempt.
+		"retry_initial_backoff_secs": backoffSeconds,
+		"retry_max_duration_secs":    backoffSeconds,
+	}, nil
+}
+
+// retrieveSecret retrieves a secret in the same namespace as the export policy.
+// This will return an error if the secret does not exist.
+func retrieveSecret(ctx context.Context, client client.Client, name string, exportPolicy *v1alpha1.ExportPolicy) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := client.Get(ctx, types.NamespacedName{
+		Name:      name,
+		Namespace: exportPolicy.Namespace,
+	}, secret)
+
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("secret '%s' not found", name)
+	} else if err != nil {
+		log.FromContext(ctx).Error(err, "failed to get secret", "secret", name)
+		return nil, fmt.Errorf("internal error when retrieving secret")
+	}
+
+	return secret, nil
+}
+
+// retrieveBasicAuthSecret retrieves the basic auth secret for the prometheus.
+// This will return an error if the secret does not exist, is not of the
+// correct type, or if the secret data does not contain the expected keys.
+func retrieveBasicAuthSecret(ctx context.Context, client client.Client, secretRef v1alpha1.LocalSecretReference, exportPolicy *v1alpha1.ExportPolicy) (*corev1.Secret, error) {
+	secret, err := retrieveSecret(ctx, client, secretRef.Name, exportPolicy)
+	if err != nil {
+		return nil, err
+	} else if secret.Type != corev1.SecretTypeBasicAuth {
+		return nil, fmt.Errorf("secret '%s' is not of type kubernetes.io/basic-auth", secretRef.Name)
+	} else if _, ok := secret.Data["username"]; !ok {
+		return nil, fmt.Errorf("secret '%s' does not contain a username", secretRef.Name)
+	} else if _, ok := secret.Data["password"]; !ok {
+		return nil, fmt.Errorf("secret '%s' does not contain a password", secretRef.Name)
+	}
+
+	return secret, nil
+}
+
+// retrieveAWSCredentialsSecret retrieves a secret containing AWS access keys.
+// This will return an error if the secret does not exist or if the secret data
+// does not contain the expected keys.
+func retrieveAWSCredentialsSecret(ctx context.Context, client client.Client, secretRef v1alpha1.LocalSecretReference, exportPolicy *v1alpha1.ExportPolicy) (*corev1.Secret, error) {
+	secret, err := retrieveSecret(ctx, client, secretRef.Name, exportPolicy)
+	if err != nil {
+		return nil, err
+	} else if len(secret.Data["access-key-id"]) == 0 {
+		return nil, fmt.Errorf("secret '%s' does not contain an access-key-id", secretRef.Name)
+	} else if len(secret.Data["secret-access-key"]) == 0 {
+		return nil, fmt.Errorf("secret '%s' does not contain a secret-access-key", secretRef.Name)
+	}
+
+	return secret, nil
+}
+
+// retrieveTLSSecret retrieves a secret containing a certificate and private
+// key. This will return an error if the secret does not exist, is not of the
+// correct type, or if the secret data does not contain the expected keys.
+func retrieveTLSSecret(ctx context.Context, client client.Client, secretRef v1alpha1.LocalSecretReference, exportPolicy *v1alpha1.ExportPolicy) (*corev1.Secret, error) {
+	secret, err := retrieveSecret(ctx, client, secretRef.Name, exportPolicy)
+	if err != nil {
+		return nil, err
+	} else if secret.Type != corev1.SecretTypeTLS {
+		return nil, fmt.Errorf("secret '%s' is not of type kubernetes.io/tls", secretRef.Name)
+	} else if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, fmt.Errorf("secret '%s' does not contain a certificate", secretRef.Name)
+	} else if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("secret '%s' does not contain a private key", secretRef.Name)
+	}
+
+	return secret, nil
+}
+
+// retrieveSecretKey retrieves the value of a key in a secret. This will return
+// an error if the secret does not exist or if the secret data does not contain
+// the key.
+func retrieveSecretKey(ctx context.Context, client client.Client, secretKeyRef v1alpha1.LocalSecretKeyReference, exportPolicy *v1alpha1.ExportPolicy) ([]byte, error) {
+	secret, err := retrieveSecret(ctx, client, secretKeyRef.Name, exportPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[secretKeyRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret '%s' does not contain the key '%s'", secretKeyRef.Name, secretKeyRef.Key)
+	}
+
+	return value, nil
+}
+
+// retrieveConfigMapKey retrieves the value of a key in a config map in the same
+// namespace as the export policy. This will return an error if the config map
+// does not exist or if the config map does not contain the key.
+func retrieveConfigMapKey(ctx context.Context, client client.Client, configMapKeyRef v1alpha1.LocalConfigMapKeyReference, exportPolicy *v1alpha1.ExportPolicy) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{
+		Name:      configMapKeyRef.Name,
+		Namespace: exportPolicy.Namespace,
+	}, configMap)
+
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("config map '%s' not found", configMapKeyRef.Name)
+	} else if err != nil {
+		log.FromContext(ctx).Error(err, "failed to get config map", "configmap", configMapKeyRef.Name)
+		return nil, fmt.Errorf("internal error when retrieving config map")
+	}
+
+	if value, ok := configMap.Data[configMapKeyRef.Key]; ok {
+		return []byte(value), nil
+	} else if value, ok := configMap.BinaryData[configMapKeyRef.Key]; ok {
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("config map '%s' does not contain the key '%s'", configMapKeyRef.Name, configMapKeyRef.Key)
 }
@@ -2,13 +2,31 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/VictoriaMetrics/metricsql"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	"go.datum.net/telemetry-services-operator/api/v1alpha1"
 )
@@ -17,7 +35,10 @@ func TestCreateVectorConfiguration(t *testing.T) {
 	tests := []struct {
 		name         string
 		exportPolicy *v1alpha1.ExportPolicy
-		assert       func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any)
+		// Objects that exist in the cluster when the vector configuration is
+		// created.
+		objects []client.Object
+		assert  func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any)
 	}{
 		{
 			name:         "project filter is present when no filters are specified",
@@ -57,6 +78,146 @@ func TestCreateVectorConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "metric selectors are compiled into a query",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0].Metrics = &v1alpha1.MetricSource{
+					Selector: &v1alpha1.MetricSelector{
+						ServiceName:   "networking.miloapis.com",
+						ResourceKinds: []string{"Gateway", "HTTPProxy"},
+						MetricNames:   []string{"gateway_request_*"},
+						LabelMatchers: []v1alpha1.LabelMatcher{
+							{Label: "code", Operator: v1alpha1.LabelMatchOperatorNotRegexMatch, Value: "5.."},
+						},
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSources := vectorConfig["sources"].(map[string]any)
+
+				if assert.Len(t, vectorSources, 1) {
+					source := vectorSources[getVectorComponentID(ep, "test-project", "source", vectorSource)].(map[string]any)
+					query := source["query"].(map[string]any)
+					assert.Equal(t, []string{
+						`{__name__=~"gateway_request_.*",code!~"5..",resource_kind=~"Gateway|HTTPProxy",service_name="networking.miloapis.com",resourcemanager_datumapis_com_project_name="test-project"}`,
+					}, query["match[]"])
+				}
+			},
+		},
+		{
+			name: "aggregated metric sources query the aggregates and convert them into metrics",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0].Metrics = &v1alpha1.MetricSource{
+					MetricsQL: `{resource_kind="Gateway"}`,
+					Aggregation: &v1alpha1.MetricAggregation{
+						Window:   metav1.Duration{Duration: time.Minute},
+						Function: v1alpha1.MetricAggregationFunctionSum,
+						By:       []string{"resource_name"},
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				sourceID := getVectorComponentID(ep, "test-project", "source", vectorSource)
+				resultsID := getVectorComponentID(ep, "test-project", "source-aggregate-results", vectorTransform)
+				metricsID := getVectorComponentID(ep, "test-project", "source-aggregate-metrics", vectorTransform)
+
+				source := vectorConfig["sources"].(map[string]any)[sourceID].(map[string]any)
+				assert.Equal(t, "http_client", source["type"])
+				assert.Equal(t, "https://metrics.example.com/api/v1/query", source["endpoint"])
+				assert.Equal(t, int64(60), source["scrape_interval_secs"])
+
+				query := source["query"].(map[string]any)["query"].([]string)
+				if assert.Len(t, query, 1) {
+					assert.Equal(t, `sum by (__name__, resourcemanager_datumapis_com_project_name, resource_name) (last_over_time({resource_kind="Gateway",resourcemanager_datumapis_com_project_name="test-project"}[60s]) keep_metric_names)`, query[0])
+					_, err := metricsql.Parse(query[0])
+					assert.NoError(t, err)
+				}
+
+				transforms := vectorConfig["transforms"].(map[string]any)
+				if assert.Contains(t, transforms, resultsID) && assert.Contains(t, transforms, metricsID) {
+					assert.Equal(t, []string{sourceID}, transforms[resultsID].(map[string]any)["inputs"])
+					assert.Contains(t, transforms[resultsID].(map[string]any)["source"], `"gauge": {"value"`)
+					assert.Equal(t, []string{resultsID}, transforms[metricsID].(map[string]any)["inputs"])
+				}
+
+				sink := vectorConfig["sinks"].(map[string]any)[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+				assert.Equal(t, []string{metricsID}, sink["inputs"])
+			},
+		},
+		{
+			name: "counters are aggregated by their increase and published as counters",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0].Metrics = &v1alpha1.MetricSource{
+					MetricsQL: `{__name__="gateway_requests_total"}`,
+					Aggregation: &v1alpha1.MetricAggregation{
+						Window:   metav1.Duration{Duration: 5 * time.Minute},
+						Function: v1alpha1.MetricAggregationFunctionIncrease,
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				sourceID := getVectorComponentID(ep, "test-project", "source", vectorSource)
+				resultsID := getVectorComponentID(ep, "test-project", "source-aggregate-results", vectorTransform)
+
+				source := vectorConfig["sources"].(map[string]any)[sourceID].(map[string]any)
+				query := source["query"].(map[string]any)["query"].([]string)
+				if assert.Len(t, query, 1) {
+					assert.Equal(t, `sum by (__name__, resourcemanager_datumapis_com_project_name) (increase(gateway_requests_total{resourcemanager_datumapis_com_project_name="test-project"}[300s]) keep_metric_names)`, query[0])
+					_, err := metricsql.Parse(query[0])
+					assert.NoError(t, err)
+				}
+
+				results := vectorConfig["transforms"].(map[string]any)[resultsID].(map[string]any)
+				assert.Contains(t, results["source"], `"kind": "incremental"`)
+				assert.Contains(t, results["source"], `"counter": {"value"`)
+				assert.NotContains(t, results["source"], `"gauge"`)
+			},
+		},
+		{
+			name: "sources with unsupported queries are skipped",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = append(ep.Spec.Sources,
+					v1alpha1.TelemetrySource{
+						Name:    "rate",
+						Metrics: &v1alpha1.MetricSource{MetricsQL: `rate(http_requests_total[5m])`},
+					},
+					v1alpha1.TelemetrySource{
+						Name:    "invalid",
+						Metrics: &v1alpha1.MetricSource{MetricsQL: `{job=`},
+					},
+				)
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSources := vectorConfig["sources"].(map[string]any)
+
+				if assert.Len(t, vectorSources, 1) {
+					assert.Contains(t, vectorSources, getVectorComponentID(ep, "test-project", "source", vectorSource))
+				}
+			},
+		},
+		{
+			name: "sink inputs only include configured sources",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Sources = []string{"source", "missing"}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, []string{getVectorComponentID(ep, "test-project", "source", vectorSource)}, sink["inputs"])
+				}
+			},
+		},
+		{
+			name: "sinks without configured sources are skipped",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Sources = []string{"missing"}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
 		{
 			name: "matching source and sink name produces unique component names",
 			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
@@ -79,19 +240,1081 @@ func TestCreateVectorConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "batch and retry settings are applied to the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch = v1alpha1.Batch{
+					Timeout: metav1.Duration{Duration: 1500 * time.Millisecond},
+					MaxSize: 1000,
+				}
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry = v1alpha1.Retry{
+					MaxAttempts:     5,
+					BackoffDuration: metav1.Duration{Duration: 10 * time.Second},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, map[string]any{
+						"timeout_secs": 1.5,
+						"max_events":   1000,
+					}, sink["batch"])
+					assert.Equal(t, map[string]any{
+						"retry_attempts":             4,
+						"retry_initial_backoff_secs": int64(10),
+						"retry_max_duration_secs":    int64(10),
+					}, sink["request"])
+				}
+			},
+		},
+		{
+			name: "buffer and acknowledgement settings are applied to the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				maxSize := resource.MustParse("1Gi")
+				ep.Spec.Sinks[0].Buffer = &v1alpha1.SinkBuffer{
+					Type:     v1alpha1.SinkBufferTypeDisk,
+					MaxSize:  &maxSize,
+					WhenFull: v1alpha1.SinkBufferWhenFullDropNewest,
+				}
+				ep.Spec.Sinks[0].Acknowledgements = true
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, map[string]any{
+						"type":      "disk",
+						"max_size":  int64(1024 * 1024 * 1024),
+						"when_full": "drop_newest",
+					}, sink["buffer"])
+					assert.Equal(t, map[string]any{"enabled": true}, sink["acknowledgements"])
+				}
+			},
+		},
+		{
+			name: "sink is skipped when the disk buffer is too small",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				maxSize := resource.MustParse("10Mi")
+				ep.Spec.Sinks[0].Buffer = &v1alpha1.SinkBuffer{
+					Type:    v1alpha1.SinkBufferTypeDisk,
+					MaxSize: &maxSize,
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "sink is skipped when the disk buffer is too large",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Buffer = &v1alpha1.SinkBuffer{
+					Type:    v1alpha1.SinkBufferTypeDisk,
+					MaxSize: ptr.To(resource.MustParse("3Gi")),
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "sinks are skipped when the disk buffers exceed the export policy's budget",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Buffer = &v1alpha1.SinkBuffer{
+					Type:    v1alpha1.SinkBufferTypeDisk,
+					MaxSize: ptr.To(resource.MustParse("2Gi")),
+				}
+				for _, name := range []string{"second", "third"} {
+					sink := *ep.Spec.Sinks[0].DeepCopy()
+					sink.Name = name
+					ep.Spec.Sinks = append(ep.Spec.Sinks, sink)
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				assert.Len(t, vectorSinks, 2)
+				assert.Contains(t, vectorSinks, getVectorComponentID(ep, "test-project", "sink", vectorSink))
+				assert.Contains(t, vectorSinks, getVectorComponentID(ep, "test-project", "second", vectorSink))
+			},
+		},
+		{
+			name: "sink is skipped when the retry backoff can not be represented",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry.BackoffDuration = metav1.Duration{Duration: 1500 * time.Millisecond}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "opentelemetry http sink is configured with headers and encoding",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					OpenTelemetry: &v1alpha1.OpenTelemetrySink{
+						HTTP: &v1alpha1.OpenTelemetryHTTPSink{
+							Endpoint: "https://api.honeycomb.io/v1/metrics",
+							Headers: []v1alpha1.HTTPHeader{
+								{Name: "x-honeycomb-dataset", Value: "datum"},
+							},
+							Batch: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+							Retry: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+						},
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, "opentelemetry", sink["type"])
+
+					protocol := sink["protocol"].(map[string]any)
+					assert.Equal(t, "http", protocol["type"])
+					assert.Equal(t, "https://api.honeycomb.io/v1/metrics", protocol["uri"])
+					assert.Equal(t, map[string]any{
+						"codec": "protobuf",
+						"protobuf": map[string]any{
+							"desc_file":    otlpProtobufDescriptorFile,
+							"message_type": otlpMetricsMessageType,
+						},
+					}, protocol["encoding"])
+					assert.Equal(t, map[string]any{"method": "bytes"}, protocol["framing"])
+
+					request := protocol["request"].(map[string]any)
+					assert.Equal(t, map[string]string{
+						"content-type":        "application/x-protobuf",
+						"x-honeycomb-dataset": "datum",
+					}, request["headers"])
+
+					// Metrics are shaped into OTLP export requests before
+					// they're encoded.
+					conversionID := getVectorComponentID(ep, "test-project", "sink-metric-to-log", vectorTransform)
+					requestID := getVectorComponentID(ep, "test-project", "sink-otlp-metrics", vectorTransform)
+					assert.Equal(t, []string{requestID}, sink["inputs"])
+
+					vectorTransforms := vectorConfig["transforms"].(map[string]any)
+					if assert.Len(t, vectorTransforms, 2) {
+						assert.Equal(t, "metric_to_log", vectorTransforms[conversionID].(map[string]any)["type"])
+
+						transform := vectorTransforms[requestID].(map[string]any)
+						assert.Equal(t, []string{conversionID}, transform["inputs"])
+						assert.Equal(t, "remap", transform["type"])
+						assert.Contains(t, transform["source"], `"resource_metrics"`)
+					}
+				}
+			},
+		},
+		{
+			name: "datadog metrics sink is configured with the api key and site",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					DatadogMetrics: &v1alpha1.DatadogMetricsSink{
+						APIKeySecretRef: v1alpha1.LocalSecretKeyReference{
+							Name: "datadog",
+							Key:  "api-key",
+						},
+						Site:  v1alpha1.DatadogSiteEU1,
+						Batch: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+						Retry: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "datadog", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"api-key": []byte("datadog-api-key"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, "datadog_metrics", sink["type"])
+					assert.Equal(t, "datadog-api-key", sink["default_api_key"])
+					assert.Equal(t, "datadoghq.eu", sink["site"])
+				}
+			},
+		},
+		{
+			name: "bearer token authentication is added to the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Authentication = &v1alpha1.Authentication{
+					BearerToken: &v1alpha1.BearerTokenAuthentication{
+						SecretKeyRef: v1alpha1.LocalSecretKeyReference{
+							Name: "mimir",
+							Key:  "token",
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "mimir", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"token": []byte("my-token"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, map[string]any{
+						"strategy": "bearer",
+						"token":    "my-token",
+					}, sink["auth"])
+				}
+			},
+		},
+		{
+			name: "sink is skipped when the bearer token key is missing",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Authentication = &v1alpha1.Authentication{
+					BearerToken: &v1alpha1.BearerTokenAuthentication{
+						SecretKeyRef: v1alpha1.LocalSecretKeyReference{
+							Name: "mimir",
+							Key:  "token",
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "mimir", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeOpaque,
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "headers are added to the sink with values sourced from secrets",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Headers = []v1alpha1.HTTPHeader{
+					{Name: "X-Scope-OrgID", Value: "tenant-1"},
+					{
+						Name: "X-Api-Key",
+						SecretKeyRef: &v1alpha1.LocalSecretKeyReference{
+							Name: "vendor",
+							Key:  "api-key",
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vendor", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"api-key": []byte("secret-api-key"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					request := sink["request"].(map[string]any)
+					assert.Equal(t, map[string]string{
+						"X-Scope-OrgID": "tenant-1",
+						"X-Api-Key":     "secret-api-key",
+					}, request["headers"])
+				}
+			},
+		},
+		{
+			name: "tls settings are added to the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.TLS = &v1alpha1.TLSConfig{
+					CABundle: &v1alpha1.CABundleSource{
+						ConfigMapKeyRef: &v1alpha1.LocalConfigMapKeyReference{
+							Name: "private-pki",
+							Key:  "ca.crt",
+						},
+					},
+					ClientCertificate: &v1alpha1.LocalSecretReference{
+						Name: "client-cert",
+					},
+					ServerName: "metrics.internal.example.com",
+				}
+			}),
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "private-pki", Namespace: "test-namespace"},
+					Data: map[string]string{
+						"ca.crt": testCertificate,
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       []byte(testCertificate),
+						corev1.TLSPrivateKeyKey: []byte("private-key"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, map[string]any{
+						"ca_file":     testCertificate,
+						"crt_file":    testCertificate,
+						"key_file":    "private-key",
+						"server_name": "metrics.internal.example.com",
+					}, sink["tls"])
+				}
+			},
+		},
+		{
+			name: "sink is skipped when the client certificate secret is not a tls secret",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.TLS = &v1alpha1.TLSConfig{
+					ClientCertificate: &v1alpha1.LocalSecretReference{
+						Name: "client-cert",
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeOpaque,
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "aws sigv4 authentication is added to the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Authentication = &v1alpha1.Authentication{
+					AWSSigV4: &v1alpha1.AWSSigV4Authentication{
+						Region:        "us-east-1",
+						Service:       "aps",
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/amp-writer",
+						SecretRef: v1alpha1.LocalSecretReference{
+							Name: "aws-credentials",
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"access-key-id":     []byte("AKIAEXAMPLE"),
+						"secret-access-key": []byte("secret"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+
+				if assert.Len(t, vectorSinks, 1) {
+					sinks := slices.Collect(maps.Keys(vectorSinks))
+
+					sink := vectorSinks[sinks[0]].(map[string]any)
+					assert.Equal(t, map[string]any{
+						"strategy":          "aws",
+						"access_key_id":     "AKIAEXAMPLE",
+						"secret_access_key": "secret",
+						"region":            "us-east-1",
+						"assume_role":       "arn:aws:iam::123456789012:role/amp-writer",
+					}, sink["auth"])
+					assert.Equal(t, map[string]any{"region": "us-east-1"}, sink["aws"])
+				}
+			},
+		},
+		{
+			name: "metrics are converted into logs for object storage sinks",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					ObjectStorage: &v1alpha1.ObjectStorageSink{
+						Bucket:    "telemetry-archive",
+						KeyPrefix: "metrics/date=%F/",
+						Region:    "us-east-1",
+						Endpoint:  "https://minio.example.com",
+						SecretRef: v1alpha1.LocalSecretReference{
+							Name: "aws-credentials",
+						},
+						Batch: v1alpha1.Batch{
+							Timeout: metav1.Duration{Duration: 5 * time.Minute},
+							MaxSize: 5000,
+						},
+						Retry: v1alpha1.Retry{
+							MaxAttempts:     3,
+							BackoffDuration: metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"access-key-id":     []byte("AKIAEXAMPLE"),
+						"secret-access-key": []byte("secret"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				conversionID := getVectorComponentID(ep, "test-project", "sink-metric-to-log", vectorTransform)
+
+				assert.Equal(t, map[string]any{
+					conversionID: map[string]any{
+						"type":   "metric_to_log",
+						"inputs": []string{getVectorComponentID(ep, "test-project", "source", vectorSource)},
+					},
+				}, vectorConfig["transforms"])
+
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "aws_s3", sink["type"])
+					assert.Equal(t, []string{conversionID}, sink["inputs"])
+					assert.Equal(t, "telemetry-archive", sink["bucket"])
+					assert.Equal(t, "metrics/date=%F/", sink["key_prefix"])
+					assert.Equal(t, "https://minio.example.com", sink["endpoint"])
+					assert.Equal(t, "gzip", sink["compression"])
+					assert.Equal(t, map[string]any{
+						"access_key_id":     "AKIAEXAMPLE",
+						"secret_access_key": "secret",
+					}, sink["auth"])
+				}
+			},
+		},
+		{
+			name: "object storage sink is skipped when a metric key prefix references a label as a field",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					ObjectStorage: &v1alpha1.ObjectStorageSink{
+						Bucket:    "telemetry-archive",
+						KeyPrefix: "metrics/{{ resource_name }}/",
+						Region:    "us-east-1",
+						SecretRef: v1alpha1.LocalSecretReference{
+							Name: "aws-credentials",
+						},
+						Batch: v1alpha1.Batch{
+							Timeout: metav1.Duration{Duration: 5 * time.Minute},
+							MaxSize: 5000,
+						},
+						Retry: v1alpha1.Retry{
+							MaxAttempts:     3,
+							BackoffDuration: metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"access-key-id":     []byte("AKIAEXAMPLE"),
+						"secret-access-key": []byte("secret"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "metrics are streamed to kafka sinks with SASL credentials",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					Kafka: &v1alpha1.KafkaSink{
+						Brokers: []string{"kafka-0.example.com:9093", "kafka-1.example.com:9093"},
+						Topic:   "telemetry-{{ tags.resource_kind }}",
+						SASL: &v1alpha1.KafkaSASL{
+							SecretRef: v1alpha1.LocalSecretReference{Name: "kafka-credentials"},
+						},
+						TLS:      &v1alpha1.TLSConfig{ServerName: "kafka.example.com"},
+						Encoding: v1alpha1.KafkaEncodingProtobuf,
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "kafka-credentials", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						"username": []byte("telemetry"),
+						"password": []byte("secret"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "kafka", sink["type"])
+					assert.Equal(t, []string{getVectorComponentID(ep, "test-project", "source", vectorSource)}, sink["inputs"])
+					assert.Equal(t, "kafka-0.example.com:9093,kafka-1.example.com:9093", sink["bootstrap_servers"])
+					assert.Equal(t, "telemetry-{{ tags.resource_kind }}", sink["topic"])
+					assert.Equal(t, map[string]any{"codec": "native"}, sink["encoding"])
+					assert.Equal(t, map[string]any{
+						"enabled":   true,
+						"mechanism": "SCRAM-SHA-512",
+						"username":  "telemetry",
+						"password":  "secret",
+					}, sink["sasl"])
+					assert.Equal(t, map[string]any{
+						"enabled":     true,
+						"server_name": "kafka.example.com",
+					}, sink["tls"])
+				}
+			},
+		},
+		{
+			name: "log sources are streamed to kafka sinks as json",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = []v1alpha1.TelemetrySource{
+					{
+						Name: "source",
+						Logs: &v1alpha1.LogSource{LogsQL: `level:="error"`},
+					},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					Kafka: &v1alpha1.KafkaSink{
+						Brokers: []string{"kafka-0.example.com:9093"},
+						Topic:   "telemetry-{{ resource_kind }}",
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "kafka", sink["type"])
+					assert.Equal(t, "telemetry-{{ resource_kind }}", sink["topic"])
+					assert.Equal(t, map[string]any{"codec": "json"}, sink["encoding"])
+				}
+			},
+		},
+		{
+			name: "kafka sink is skipped when a metric topic references a label as a field",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					Kafka: &v1alpha1.KafkaSink{
+						Brokers: []string{"kafka-0.example.com:9093"},
+						Topic:   "telemetry-{{ resource_kind }}",
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "log sources are published to loki sinks",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = []v1alpha1.TelemetrySource{
+					{
+						Name: "source",
+						Logs: &v1alpha1.LogSource{LogsQL: `level:="error"`},
+					},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					Loki: &v1alpha1.LokiSink{
+						Endpoint: "https://loki.example.com",
+						TenantID: "{{ resource_namespace }}",
+						Labels: []v1alpha1.LokiLabel{
+							{Name: "kind", Value: "{{ resource_kind }}"},
+						},
+						Batch: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+						Retry: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSources := vectorConfig["sources"].(map[string]any)
+				if assert.Len(t, vectorSources, 1) {
+					source := vectorSources[getVectorComponentID(ep, "test-project", "source", vectorSource)].(map[string]any)
+					assert.Equal(t, "http_client", source["type"])
+					assert.Equal(t, map[string]any{
+						"query": map[string]any{
+							"type": "vrl",
+							"value": `end = to_unix_timestamp(now()) - 60
+end = end - end % 30
+"_time:[" + to_string(end - 30) + ", " + to_string(end) + ") (" + "level:=\"error\"" + ")"`,
+						},
+						"extra_filters": []string{`resourcemanager_datumapis_com_project_name:="test-project"`},
+					}, source["query"])
+				}
+
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "loki", sink["type"])
+					assert.Equal(t, "{{ resource_namespace }}", sink["tenant_id"])
+					assert.Equal(t, map[string]string{"kind": "{{ resource_kind }}"}, sink["labels"])
+				}
+			},
+		},
+		{
+			name: "log sources are published to splunk hec sinks",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = []v1alpha1.TelemetrySource{
+					{
+						Name: "source",
+						Logs: &v1alpha1.LogSource{LogsQL: `level:="error"`},
+					},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					SplunkHEC: &v1alpha1.SplunkHECSink{
+						Endpoint: "https://splunk.example.com:8088",
+						TokenSecretKeyRef: v1alpha1.LocalSecretKeyReference{
+							Name: "splunk",
+							Key:  "token",
+						},
+						Index:      "{{ resource_namespace }}",
+						SourceType: "datum:telemetry",
+						Batch:      ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+						Retry:      ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "splunk", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"token": []byte("hec-token"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "splunk_hec_logs", sink["type"])
+					assert.Equal(t, "https://splunk.example.com:8088", sink["endpoint"])
+					assert.Equal(t, "hec-token", sink["default_token"])
+					assert.Equal(t, "{{ resource_namespace }}", sink["index"])
+					assert.Equal(t, "datum:telemetry", sink["sourcetype"])
+				}
+			},
+		},
+		{
+			name: "log sources are published to elasticsearch data streams with an API key",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = []v1alpha1.TelemetrySource{
+					{
+						Name: "source",
+						Logs: &v1alpha1.LogSource{LogsQL: `level:="error"`},
+					},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					Elasticsearch: &v1alpha1.ElasticsearchSink{
+						Endpoints: []string{"https://elasticsearch.example.com:9200"},
+						DataStream: &v1alpha1.ElasticsearchDataStream{
+							Type:      "logs",
+							Dataset:   "telemetry",
+							Namespace: "{{ resource_namespace }}",
+						},
+						Authentication: &v1alpha1.ElasticsearchAuthentication{
+							APIKey: &v1alpha1.APIKeyAuthentication{
+								SecretKeyRef: v1alpha1.LocalSecretKeyReference{
+									Name: "elasticsearch",
+									Key:  "api-key",
+								},
+							},
+						},
+						Batch: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+						Retry: ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+					},
+				}
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch", Namespace: "test-namespace"},
+					Data: map[string][]byte{
+						"api-key": []byte("encoded-api-key"),
+					},
+				},
+			},
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, "elasticsearch", sink["type"])
+					assert.Equal(t, []string{"https://elasticsearch.example.com:9200"}, sink["endpoints"])
+					assert.Equal(t, "data_stream", sink["mode"])
+					assert.Equal(t, map[string]any{
+						"type":      "logs",
+						"dataset":   "telemetry",
+						"namespace": "{{ resource_namespace }}",
+					}, sink["data_stream"])
+					assert.NotContains(t, sink, "bulk")
+					assert.NotContains(t, sink, "auth")
+					assert.Equal(t, map[string]string{
+						"Authorization": "ApiKey encoded-api-key",
+					}, sink["request"].(map[string]any)["headers"])
+				}
+			},
+		},
+		{
+			name: "sinks with incompatible sources are skipped",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0] = v1alpha1.TelemetrySource{
+					Name: "source",
+					Logs: &v1alpha1.LogSource{LogsQL: `*`},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Len(t, vectorConfig["sources"], 1)
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "trace sources are published to opentelemetry sinks",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources = []v1alpha1.TelemetrySource{
+					{
+						Name: "source",
+						Traces: &v1alpha1.TraceSource{
+							ServiceNames: []string{"networking.miloapis.com"},
+							ResourceAttributes: []v1alpha1.ResourceAttributeMatch{
+								{Key: "resource.kind", Value: "Gateway"},
+							},
+						},
+					},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					OpenTelemetry: &v1alpha1.OpenTelemetrySink{
+						HTTP: &v1alpha1.OpenTelemetryHTTPSink{
+							Endpoint: "https://api.honeycomb.io/v1/traces",
+							Encoding: v1alpha1.OpenTelemetryEncodingProtobuf,
+							Batch:    ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Batch,
+							Retry:    ep.Spec.Sinks[0].Target.PrometheusRemoteWrite.Retry,
+						},
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				sourceID := getVectorComponentID(ep, "test-project", "source", vectorSource)
+
+				assert.Empty(t, vectorConfig["sources"])
+				vectorTransforms := vectorConfig["transforms"].(map[string]any)
+				if assert.Contains(t, vectorTransforms, sourceID) {
+					transform := vectorTransforms[sourceID].(map[string]any)
+					assert.Equal(t, []string{platformTracesComponentID}, transform["inputs"])
+					assert.Contains(t, transform["source"], `attributes."resourcemanager.datumapis.com/project-name" == "test-project"`)
+					assert.Contains(t, transform["source"], `includes(["networking.miloapis.com"], attributes."service.name")`)
+					assert.Contains(t, transform["source"], `attributes."resource.kind" == "Gateway"`)
+				}
+
+				vectorSinks := vectorConfig["sinks"].(map[string]any)
+				if assert.Len(t, vectorSinks, 1) {
+					sink := vectorSinks[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+					assert.Equal(t, []string{sourceID}, sink["inputs"])
+
+					encoding := sink["protocol"].(map[string]any)["encoding"].(map[string]any)
+					assert.Equal(t, otlpTracesMessageType, encoding["protobuf"].(map[string]any)["message_type"])
+
+					// Trace sinks can't apply backpressure to the shared
+					// platform trace receiver.
+					assert.Equal(t, map[string]any{"type": "memory", "when_full": "drop_newest"}, sink["buffer"])
+				}
+			},
+		},
+		{
+			name: "trace sources are not published to metrics sinks",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0] = v1alpha1.TelemetrySource{
+					Name:   "source",
+					Traces: &v1alpha1.TraceSource{},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Len(t, vectorConfig["transforms"], 1)
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
+		{
+			name: "sink transforms are inserted between the sources and the sink",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Transforms = &v1alpha1.SinkTransforms{
+					IncludeMetrics: []string{"gateway_.+"},
+					ExcludeMetrics: []string{".*_bucket"},
+					DropLabels:     []string{"instance"},
+					AddLabels:      []v1alpha1.StaticLabel{{Name: "env", Value: "prod"}},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				sourceID := getVectorComponentID(ep, "test-project", "source", vectorSource)
+				filterID := getVectorComponentID(ep, "test-project", "sink-filter", vectorTransform)
+				labelsID := getVectorComponentID(ep, "test-project", "sink-labels", vectorTransform)
+
+				assert.Equal(t, map[string]any{
+					filterID: map[string]any{
+						"type":      "filter",
+						"inputs":    []string{sourceID},
+						"condition": "name = string(.name) ?? \"\"\nmatch_any(name, [r'^(?:gateway_.+)$']) && !match_any(name, [r'^(?:.*_bucket)$'])\n",
+					},
+					labelsID: map[string]any{
+						"type":   "remap",
+						"inputs": []string{filterID},
+						"source": ".tags = filter(object(.tags) ?? {}) -> |key, _value| { !includes([\"instance\"], key) }\n.tags.\"env\" = \"prod\"\n",
+					},
+				}, vectorConfig["transforms"])
+
+				sink := vectorConfig["sinks"].(map[string]any)[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+				assert.Equal(t, []string{labelsID}, sink["inputs"])
+			},
+		},
+		{
+			name: "relabel configs are compiled into a remap transform",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				replacement := "edge_$1"
+				ep.Spec.Sinks[0].Transforms = &v1alpha1.SinkTransforms{
+					RelabelConfigs: []v1alpha1.RelabelConfig{
+						{
+							SourceLabels: []string{"__name__"},
+							Regex:        "gateway_(.+)",
+							TargetLabel:  "__name__",
+							Replacement:  &replacement,
+						},
+						{
+							SourceLabels: []string{"instance"},
+							TargetLabel:  "shard",
+							Modulus:      10,
+							Action:       v1alpha1.RelabelActionHashMod,
+						},
+					},
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				relabelID := getVectorComponentID(ep, "test-project", "sink-relabel", vectorTransform)
+
+				transforms := vectorConfig["transforms"].(map[string]any)
+				if assert.Len(t, transforms, 1) && assert.Contains(t, transforms, relabelID) {
+					transform := transforms[relabelID].(map[string]any)
+					assert.Equal(t, []string{getVectorComponentID(ep, "test-project", "source", vectorSource)}, transform["inputs"])
+					assert.Equal(t, true, transform["drop_on_abort"])
+					assert.Contains(t, transform["source"], `labels."__name__" = replace(value, r'^(?:gateway_(.+))$', "edge_$1")`)
+					assert.Contains(t, transform["source"], `labels."shard" = to_string(((parse_int!(slice!(hash, 16, 24), 16) % 10) * 6 + parse_int!(slice!(hash, 24), 16)) % 10)`)
+				}
+
+				sink := vectorConfig["sinks"].(map[string]any)[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+				assert.Equal(t, []string{relabelID}, sink["inputs"])
+			},
+		},
+		{
+			name: "cardinality limit is applied after the sink transforms",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sinks[0].Transforms = &v1alpha1.SinkTransforms{DropLabels: []string{"instance"}}
+				ep.Spec.Sinks[0].CardinalityLimit = &v1alpha1.CardinalityLimit{
+					ValueLimit: 100,
+					Mode:       v1alpha1.CardinalityLimitModeDropEvent,
+				}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				labelsID := getVectorComponentID(ep, "test-project", "sink-labels", vectorTransform)
+				limitID := getVectorComponentID(ep, "test-project", "sink-cardinality-limit", vectorTransform)
+
+				transforms := vectorConfig["transforms"].(map[string]any)
+				if assert.Contains(t, transforms, limitID) {
+					assert.Equal(t, map[string]any{
+						"type":                  "tag_cardinality_limit",
+						"inputs":                []string{labelsID},
+						"mode":                  "exact",
+						"value_limit":           int32(100),
+						"limit_exceeded_action": "drop_event",
+					}, transforms[limitID])
+				}
+
+				sink := vectorConfig["sinks"].(map[string]any)[getVectorComponentID(ep, "test-project", "sink", vectorSink)].(map[string]any)
+				assert.Equal(t, []string{limitID}, sink["inputs"])
+			},
+		},
+		{
+			name: "sinks with transforms are skipped when they don't publish metrics",
+			exportPolicy: newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+				ep.Spec.Sources[0] = v1alpha1.TelemetrySource{
+					Name:   "source",
+					Traces: &v1alpha1.TraceSource{},
+				}
+				ep.Spec.Sinks[0].Target = &v1alpha1.SinkTarget{
+					OpenTelemetry: &v1alpha1.OpenTelemetrySink{
+						HTTP: &v1alpha1.OpenTelemetryHTTPSink{Endpoint: "https://otel.example.com"},
+					},
+				}
+				ep.Spec.Sinks[0].Transforms = &v1alpha1.SinkTransforms{DropLabels: []string{"instance"}}
+			}),
+			assert: func(t *testing.T, ep *v1alpha1.ExportPolicy, vectorConfig map[string]any) {
+				assert.Len(t, vectorConfig["transforms"], 1)
+				assert.Empty(t, vectorConfig["sinks"])
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reconciler := &ExportPolicyReconciler{}
+			reconciler := &ExportPolicyReconciler{
+				MetricsService: MetricsService{
+					QueryEndpoint: "https://metrics.example.com",
+				},
+				LogsService: LogsService{
+					Endpoint: "https://logs.example.com/select/logsql/query",
+				},
+				TracesService: TracesService{Enabled: true},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
 
-			vectorConfig := reconciler.createVectorConfiguration(context.Background(), "test-project", nil, tt.exportPolicy)
+			vectorConfig := reconciler.createVectorConfiguration(context.Background(), "test-project", fakeClient, tt.exportPolicy)
 
 			tt.assert(t, tt.exportPolicy, vectorConfig)
 		})
 	}
 }
 
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBdGVzdA==
+-----END CERTIFICATE-----
+`
+
+// TestOpenTelemetryDescriptorSet confirms the protobuf descriptor set that the
+// rendered OpenTelemetry sinks reference is shipped with the vector deployment
+// and contains the OTLP export request messages.
+func TestOpenTelemetryDescriptorSet(t *testing.T) {
+	exportPolicy := newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+		ep.Spec.Sources = append(ep.Spec.Sources, v1alpha1.TelemetrySource{
+			Name:   "traces",
+			Traces: &v1alpha1.TraceSource{},
+		})
+		ep.Spec.Sinks = []v1alpha1.TelemetrySink{
+			{
+				Name:    "metrics",
+				Sources: []string{"source"},
+				Target: &v1alpha1.SinkTarget{
+					OpenTelemetry: &v1alpha1.OpenTelemetrySink{
+						HTTP: &v1alpha1.OpenTelemetryHTTPSink{Endpoint: "https://otel.example.com/v1/metrics"},
+					},
+				},
+			},
+			{
+				Name:    "traces",
+				Sources: []string{"traces"},
+				Target: &v1alpha1.SinkTarget{
+					OpenTelemetry: &v1alpha1.OpenTelemetrySink{
+						HTTP: &v1alpha1.OpenTelemetryHTTPSink{Endpoint: "https://otel.example.com/v1/traces"},
+					},
+				},
+			},
+		}
+		for i := range ep.Spec.Sinks {
+			ep.Spec.Sinks[i].Target.OpenTelemetry.HTTP.Batch = v1alpha1.Batch{Timeout: metav1.Duration{Duration: 5 * time.Second}, MaxSize: 500}
+			ep.Spec.Sinks[i].Target.OpenTelemetry.HTTP.Retry = v1alpha1.Retry{MaxAttempts: 3, BackoffDuration: metav1.Duration{Duration: 5 * time.Second}}
+		}
+	})
+
+	reconciler := &ExportPolicyReconciler{TracesService: TracesService{Enabled: true}}
+	vectorConfig := reconciler.createVectorConfiguration(context.Background(), "test-project", fake.NewClientBuilder().Build(), exportPolicy)
+
+	deploymentManifest, err := os.ReadFile(filepath.Join("..", "..", "config", "vector", "deployment.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	deployment := &appsv1.Deployment{}
+	if !assert.NoError(t, yaml.Unmarshal(deploymentManifest, deployment)) {
+		return
+	}
+
+	kustomizationManifest, err := os.ReadFile(filepath.Join("..", "..", "config", "vector", "kustomization.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	kustomization := struct {
+		ConfigMapGenerator []struct {
+			Name  string   `json:"name"`
+			Files []string `json:"files"`
+		} `json:"configMapGenerator"`
+	}{}
+	if !assert.NoError(t, yaml.Unmarshal(kustomizationManifest, &kustomization)) {
+		return
+	}
+
+	sinks := vectorConfig["sinks"].(map[string]any)
+	assert.Len(t, sinks, 2)
+	for sinkID, sink := range sinks {
+		encoding := sink.(map[string]any)["protocol"].(map[string]any)["encoding"].(map[string]any)
+		protobuf := encoding["protobuf"].(map[string]any)
+		descFile := protobuf["desc_file"].(string)
+
+		// Find the config map that's mounted at the descriptor's directory.
+		var configMapName string
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, mount := range container.VolumeMounts {
+				if mount.MountPath != filepath.Dir(descFile) {
+					continue
+				}
+				for _, volume := range deployment.Spec.Template.Spec.Volumes {
+					if volume.Name == mount.Name && volume.ConfigMap != nil {
+						assert.False(t, ptr.Deref(volume.ConfigMap.Optional, false), "descriptor config map must not be optional")
+						configMapName = volume.ConfigMap.Name
+					}
+				}
+			}
+		}
+		if !assert.NotEmpty(t, configMapName, "descriptor file %s is not mounted into vector", descFile) {
+			continue
+		}
+
+		// Confirm the config map is generated with the descriptor file.
+		var generated bool
+		for _, generator := range kustomization.ConfigMapGenerator {
+			if generator.Name == configMapName && slices.Contains(generator.Files, filepath.Base(descFile)) {
+				generated = true
+			}
+		}
+		assert.True(t, generated, "config map %s does not contain %s", configMapName, filepath.Base(descFile))
+
+		descriptorSet, err := os.ReadFile(filepath.Join("..", "..", "config", "vector", filepath.Base(descFile)))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+		if !assert.NoError(t, proto.Unmarshal(descriptorSet, fileDescriptorSet)) {
+			continue
+		}
+		files, err := protodesc.NewFiles(fileDescriptorSet)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		messageType := protobuf["message_type"].(string)
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+		if assert.NoError(t, err, "sink %s", sinkID) {
+			assert.Implements(t, (*protoreflect.MessageDescriptor)(nil), descriptor)
+		}
+	}
+}
+
 func newExportPolicy(opts ...func(*v1alpha1.ExportPolicy)) *v1alpha1.ExportPolicy {
 	p := &v1alpha1.ExportPolicy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -113,7 +1336,17 @@ func newExportPolicy(opts ...func(*v1alpha1.ExportPolicy)) *v1alpha1.ExportPolic
 					Name:    "sink",
 					Sources: []string{"source"},
 					Target: &v1alpha1.SinkTarget{
-						PrometheusRemoteWrite: &v1alpha1.PrometheusRemoteWriteSink{},
+						PrometheusRemoteWrite: &v1alpha1.PrometheusRemoteWriteSink{
+							Endpoint: "https://prometheus.example.com/api/v1/push",
+							Batch: v1alpha1.Batch{
+								Timeout: metav1.Duration{Duration: 5 * time.Second},
+								MaxSize: 500,
+							},
+							Retry: v1alpha1.Retry{
+								MaxAttempts:     3,
+								BackoffDuration: metav1.Duration{Duration: 5 * time.Second},
+							},
+						},
 					},
 				},
 			},
@@ -126,3 +1359,100 @@ func newExportPolicy(opts ...func(*v1alpha1.ExportPolicy)) *v1alpha1.ExportPolic
 
 	return p
 }
+
+func TestVectorBufferGC(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	exportPolicy := newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+		ep.Spec.Sinks[0].Buffer = &v1alpha1.SinkBuffer{
+			Type:    v1alpha1.SinkBufferTypeDisk,
+			MaxSize: ptr.To(resource.MustParse("256Mi")),
+		}
+	})
+	reconciler := &ExportPolicyReconciler{}
+	vectorConfig := reconciler.createVectorConfiguration(context.Background(), "test-project", fake.NewClientBuilder().Build(), exportPolicy)
+	vectorConfigJSON, err := json.MarshalIndent(vectorConfig, "", "  ")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	configDir, bufferDir := t.TempDir(), t.TempDir()
+	if !assert.NoError(t, os.WriteFile(filepath.Join(configDir, string(exportPolicy.UID)+".json"), vectorConfigJSON, 0o644)) {
+		return
+	}
+
+	removedPolicy := newExportPolicy()
+	configured := getVectorComponentID(exportPolicy, "test-project", "sink", vectorSink)
+	removed := getVectorComponentID(removedPolicy, "test-project", "sink", vectorSink)
+	recentlyRemoved := getVectorComponentID(removedPolicy, "test-project", "other", vectorSink)
+
+	old := time.Now().Add(-time.Hour)
+	for _, id := range []string{configured, removed, recentlyRemoved} {
+		if !assert.NoError(t, os.Mkdir(filepath.Join(bufferDir, id), 0o755)) {
+			return
+		}
+		if id != recentlyRemoved {
+			assert.NoError(t, os.Chtimes(filepath.Join(bufferDir, id), old, old))
+		}
+	}
+
+	cmd := exec.Command("sh", filepath.Join("..", "..", "config", "vector", "buffer-gc.sh"), "--once")
+	cmd.Env = append(os.Environ(), "CONFIG_DIR="+configDir, "BUFFER_DIR="+bufferDir)
+	output, err := cmd.CombinedOutput()
+	if !assert.NoError(t, err, string(output)) {
+		return
+	}
+
+	assert.DirExists(t, filepath.Join(bufferDir, configured))
+	assert.DirExists(t, filepath.Join(bufferDir, recentlyRemoved))
+	assert.NoDirExists(t, filepath.Join(bufferDir, removed))
+}
+
+func TestPlatformTraceReceiver(t *testing.T) {
+	manifest, err := os.ReadFile(filepath.Join("..", "..", "config", "vector", "traces", "traces-vector-config.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	vectorConfig := map[string]any{}
+	if !assert.NoError(t, yaml.Unmarshal(manifest, &vectorConfig)) {
+		return
+	}
+
+	// Trace sources use the traces output of the receiver as their input.
+	sourceID := strings.TrimSuffix(platformTracesComponentID, ".traces")
+	source, ok := vectorConfig["sources"].(map[string]any)[sourceID].(map[string]any)
+	if !assert.True(t, ok, "the trace receiver source %q should be configured", sourceID) {
+		return
+	}
+
+	// Spans are routed to projects using their resource attributes, so only
+	// clients with a trusted certificate can send spans.
+	for _, protocol := range []string{"grpc", "http"} {
+		tls, _ := source[protocol].(map[string]any)["tls"].(map[string]any)
+		assert.Equal(t, true, tls["enabled"], protocol)
+		assert.Equal(t, true, tls["verify_certificate"], protocol)
+		assert.NotEmpty(t, tls["ca_file"], protocol)
+	}
+}
+
+func TestTraceSourceRequiresTraceReceiver(t *testing.T) {
+	source := v1alpha1.TelemetrySource{
+		Name:   "traces",
+		Traces: &v1alpha1.TraceSource{},
+	}
+
+	reconciler := &ExportPolicyReconciler{}
+	_, err := reconciler.getSourceVectorConfig("test-project", source)
+
+	var sourceErr *sourceConfigurationError
+	if assert.ErrorAs(t, err, &sourceErr) {
+		assert.Equal(t, "Unsupported", sourceErr.reason)
+	}
+
+	reconciler.TracesService.Enabled = true
+	_, err = reconciler.getSourceVectorConfig("test-project", source)
+	assert.NoError(t, err)
+}
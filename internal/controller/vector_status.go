@@ -0,0 +1,485 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+)
+
+const (
+	// exportPolicyGenerationAnnotation is added to the vector config secret to
+	// record the generation of the export policy the configuration was created
+	// from.
+	exportPolicyGenerationAnnotation = exportPolicyLabelDomain + "/generation"
+
+	// exportPolicyConfigUpdatedAnnotation is added to the vector config secret
+	// to record when the vector configuration was last changed.
+	exportPolicyConfigUpdatedAnnotation = exportPolicyLabelDomain + "/config-updated-at"
+
+	// vectorConfigReloadDelay is how long it's expected to take for a change to
+	// the vector config secret to be propagated to the vector pods and loaded
+	// by vector. Kubelet only periodically syncs secrets that are mounted into
+	// pods, so changes are not picked up immediately.
+	vectorConfigReloadDelay = 2 * time.Minute
+
+	// vectorStatusMinRefreshInterval is the minimum amount of time between
+	// reconciles that refresh the status that's reported from vector's
+	// internal metrics.
+	vectorStatusMinRefreshInterval = 30 * time.Second
+
+	// vectorStatusRefreshRate is the number of export policies per second whose
+	// status is refreshed. The refresh interval grows with the number of export
+	// policies so the load on the metrics service stays bounded.
+	vectorStatusRefreshRate = 5
+
+	// sinkLastSentTimeResolution is how often the last sent time of a sink is
+	// updated while the sink is sending telemetry data. This avoids updating
+	// the status of the export policy every time it's reconciled.
+	sinkLastSentTimeResolution = 5 * time.Minute
+)
+
+// exportPolicySet tracks the export policies that are reconciled so the status
+// refresh interval can be scaled with the number of export policies.
+type exportPolicySet struct {
+	mu       sync.Mutex
+	policies map[string]struct{}
+}
+
+// add adds the export policy with the key to the set.
+func (s *exportPolicySet) add(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.policies == nil {
+		s.policies = map[string]struct{}{}
+	}
+	s.policies[key] = struct{}{}
+}
+
+// remove removes the export policy with the key from the set.
+func (s *exportPolicySet) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.policies, key)
+}
+
+// len returns the number of export policies in the set.
+func (s *exportPolicySet) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.policies)
+}
+
+// vectorStatusRefreshInterval returns how long to wait before the status of an
+// export policy is refreshed again. The interval is scaled with the number of
+// export policies and jittered so the refreshes are spread out over time.
+func vectorStatusRefreshInterval(policies int) time.Duration {
+	interval := max(vectorStatusMinRefreshInterval, time.Duration(policies)*time.Second/vectorStatusRefreshRate)
+	return wait.Jitter(interval, 0.1)
+}
+
+// reconcileVectorStatus updates the status of the export policy with the
+// status reported by vector's internal metrics. Returns true if the status was
+// changed.
+func (r *ExportPolicyReconciler) reconcileVectorStatus(ctx context.Context, projectName string, exportPolicy *v1alpha1.ExportPolicy, configSecret *corev1.Secret, vectorConfig map[string]any) bool {
+	programmedChanged := r.reconcileProgrammedCondition(ctx, exportPolicy, configSecret, vectorConfig)
+	healthChanged := r.reconcileSinkHealth(ctx, projectName, exportPolicy, vectorConfig)
+	cardinalityChanged := r.reconcileSinkCardinality(ctx, projectName, exportPolicy, vectorConfig)
+	return programmedChanged || healthChanged || cardinalityChanged
+}
+
+// reconcileProgrammedCondition updates the Programmed condition of the export
+// policy by confirming vector reports internal metrics for every source and
+// sink in the vector configuration. Returns true if the status was changed.
+func (r *ExportPolicyReconciler) reconcileProgrammedCondition(ctx context.Context, exportPolicy *v1alpha1.ExportPolicy, configSecret *corev1.Secret, vectorConfig map[string]any) bool {
+	condition := metav1.Condition{
+		Type:               "Programmed",
+		ObservedGeneration: exportPolicy.Generation,
+	}
+
+	expectedComponents := vectorComponentIDs(vectorConfig, "sources", "sinks")
+
+	switch {
+	case !r.MetricsService.QueryEnabled():
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "MetricsUnavailable"
+		condition.Message = "The status of the vector configuration can not be determined."
+	case len(expectedComponents) == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoComponents"
+		condition.Message = "The export policy does not have any accepted sources and sinks to configure."
+	case vectorConfigRecentlyUpdated(configSecret):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Pending"
+		condition.Message = "Waiting for vector to load the latest configuration."
+	default:
+		loadedComponents, err := r.loadedVectorComponents(ctx, exportPolicy)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to retrieve the vector components that are loaded")
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "MetricsQueryFailed"
+			condition.Message = "The status of the vector configuration could not be retrieved."
+			break
+		}
+
+		var missing int
+		for _, component := range expectedComponents {
+			if !slices.Contains(loadedComponents, component) {
+				missing++
+			}
+		}
+
+		if missing > 0 {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Pending"
+			condition.Message = fmt.Sprintf("%d/%d components have been loaded by vector.", len(expectedComponents)-missing, len(expectedComponents))
+			break
+		}
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Programmed"
+		condition.Message = fmt.Sprintf("Vector is running the configuration for generation %d.", vectorConfigGeneration(configSecret))
+	}
+
+	changed := apimeta.SetStatusCondition(&exportPolicy.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionTrue {
+		generation := vectorConfigGeneration(configSecret)
+		if exportPolicy.Status.ProgrammedGeneration != generation {
+			exportPolicy.Status.ProgrammedGeneration = generation
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// loadedVectorComponents returns the IDs of the vector components of the
+// export policy that vector currently reports internal metrics for. The
+// component metrics are labeled with the export policy's UID by the
+// component_labeler transform in the base vector configuration.
+func (r *ExportPolicyReconciler) loadedVectorComponents(ctx context.Context, exportPolicy *v1alpha1.ExportPolicy) ([]string, error) {
+	samples, err := r.MetricsService.Query(ctx, fmt.Sprintf(`group by (component_id) ({__name__=~"vector_component_.+", resource_uid=%q})`, exportPolicy.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	components := []string{}
+	for _, sample := range samples {
+		components = append(components, string(sample.Metric["component_id"]))
+	}
+	return components, nil
+}
+
+// vectorComponentIDs returns the sorted IDs of all components of the given
+// kinds in the vector configuration.
+func vectorComponentIDs(vectorConfig map[string]any, kinds ...string) []string {
+	ids := []string{}
+	for _, kind := range kinds {
+		components, ok := vectorConfig[kind].(map[string]any)
+		if !ok {
+			continue
+		}
+		ids = append(ids, slices.Collect(maps.Keys(components))...)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// vectorConfigRecentlyUpdated returns whether the vector configuration in the
+// secret was changed too recently for vector to have loaded it.
+func vectorConfigRecentlyUpdated(configSecret *corev1.Secret) bool {
+	updatedAt, err := time.Parse(time.RFC3339, configSecret.Annotations[exportPolicyConfigUpdatedAnnotation])
+	if err != nil {
+		return false
+	}
+	return time.Since(updatedAt) < vectorConfigReloadDelay
+}
+
+// vectorConfigChanged returns whether the vector configuration changed in a way
+// that vector needs time to load. Bearer tokens are ignored since OAuth2 access
+// tokens are refreshed about once per token lifetime, which doesn't change the
+// components vector has loaded.
+func vectorConfigChanged(previous, current []byte) bool {
+	var previousConfig, currentConfig any
+	if json.Unmarshal(previous, &previousConfig) != nil || json.Unmarshal(current, &currentConfig) != nil {
+		return !bytes.Equal(previous, current)
+	}
+	return !reflect.DeepEqual(withoutBearerTokens(previousConfig), withoutBearerTokens(currentConfig))
+}
+
+// withoutBearerTokens removes the token from every bearer authentication
+// strategy in the vector configuration.
+func withoutBearerTokens(config any) any {
+	switch config := config.(type) {
+	case map[string]any:
+		for key, value := range config {
+			config[key] = withoutBearerTokens(value)
+		}
+		if config["strategy"] == "bearer" {
+			delete(config, "token")
+		}
+	case []any:
+		for i, value := range config {
+			config[i] = withoutBearerTokens(value)
+		}
+	}
+	return config
+}
+
+// vectorConfigGeneration returns the generation of the export policy the
+// vector configuration in the secret was created from.
+func vectorConfigGeneration(configSecret *corev1.Secret) int64 {
+	generation, err := strconv.ParseInt(configSecret.Annotations[exportPolicyGenerationAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+// reconcileSinkHealth updates the Healthy condition, last sent time and error
+// rate of each sink using the events sent and errors reported by the sink in
+// vector's internal metrics. Returns true if the status was changed.
+func (r *ExportPolicyReconciler) reconcileSinkHealth(ctx context.Context, projectName string, exportPolicy *v1alpha1.ExportPolicy, vectorConfig map[string]any) bool {
+	var sentRates, errorRates, droppedRates map[string]float64
+	var queryErr error
+	if r.MetricsService.QueryEnabled() {
+		sentRates, queryErr = r.sinkMetricRates(ctx, exportPolicy, "vector_component_sent_events_total")
+		if queryErr == nil {
+			errorRates, queryErr = r.sinkMetricRates(ctx, exportPolicy, "vector_component_errors_total")
+		}
+		if queryErr == nil {
+			// Events that are discarded unintentionally are events the sink
+			// failed to deliver.
+			droppedRates, queryErr = r.sinkMetricRates(ctx, exportPolicy, "vector_component_discarded_events_total", `intentional="false"`)
+		}
+		if queryErr != nil {
+			log.FromContext(ctx).Error(queryErr, "failed to retrieve the health of the sinks")
+		}
+	}
+
+	sinks, _ := vectorConfig["sinks"].(map[string]any)
+
+	changed := false
+	now := time.Now()
+	for i := range exportPolicy.Status.Sinks {
+		status := &exportPolicy.Status.Sinks[i]
+		componentID := getVectorComponentID(exportPolicy, projectName, status.Name, vectorSink)
+
+		condition := metav1.Condition{
+			Type:               "Healthy",
+			ObservedGeneration: exportPolicy.Generation,
+		}
+
+		sentRate, reportsSent := sentRates[componentID]
+		errorRate, reportsErrors := errorRates[componentID]
+		droppedRate, reportsDropped := droppedRates[componentID]
+
+		switch _, configured := sinks[componentID]; {
+		case !r.MetricsService.QueryEnabled():
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "MetricsUnavailable"
+			condition.Message = "The health of the sink can not be determined."
+		case !configured:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "NotConfigured"
+			condition.Message = "The sink is not configured. Check the Accepted condition for more details."
+		case queryErr != nil:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "MetricsQueryFailed"
+			condition.Message = "The health of the sink could not be retrieved."
+		case !reportsSent && !reportsErrors:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "NoData"
+			condition.Message = "The sink has not reported any activity yet."
+		case droppedRate > 0:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Degraded"
+			condition.Message = "The sink is dropping events that could not be delivered. Check the dropped event rate for more details."
+		case errorRate > 0:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Degraded"
+			condition.Message = "The sink is reporting errors. Check the error rate for more details."
+		default:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "Healthy"
+			condition.Message = "The sink is not reporting any errors."
+		}
+
+		if apimeta.SetStatusCondition(&status.Conditions, condition) {
+			changed = true
+		}
+
+		if queryErr != nil {
+			continue
+		}
+
+		rate := ""
+		if reportsErrors {
+			rate = formatRate(errorRate)
+		}
+		if status.ErrorRate != rate {
+			status.ErrorRate = rate
+			changed = true
+		}
+
+		dropped := ""
+		if reportsDropped {
+			dropped = formatRate(droppedRate)
+		}
+		if status.DroppedEventRate != dropped {
+			status.DroppedEventRate = dropped
+			changed = true
+		}
+
+		if sentRate > 0 && (status.LastSentTime == nil || now.Sub(status.LastSentTime.Time) >= sinkLastSentTimeResolution) {
+			status.LastSentTime = &metav1.Time{Time: now.Truncate(time.Second)}
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// reconcileSinkCardinality updates the CardinalityLimited condition of each
+// sink with a cardinality limit using the number of label values that vector's
+// tag_cardinality_limit transform reports as exceeding the limit. The condition
+// is removed from sinks without a cardinality limit. Returns true if the status
+// was changed.
+func (r *ExportPolicyReconciler) reconcileSinkCardinality(ctx context.Context, projectName string, exportPolicy *v1alpha1.ExportPolicy, vectorConfig map[string]any) bool {
+	limitedSinks := map[string]v1alpha1.CardinalityLimit{}
+	for _, sink := range exportPolicy.Spec.Sinks {
+		if sink.CardinalityLimit != nil {
+			limitedSinks[sink.Name] = *sink.CardinalityLimit
+		}
+	}
+
+	var exceeded map[string]float64
+	var queryErr error
+	if len(limitedSinks) > 0 && r.MetricsService.QueryEnabled() {
+		exceeded, queryErr = r.cardinalityLimitExceeded(ctx, exportPolicy)
+		if queryErr != nil {
+			log.FromContext(ctx).Error(queryErr, "failed to retrieve the cardinality of the sinks")
+		}
+	}
+
+	transforms, _ := vectorConfig["transforms"].(map[string]any)
+
+	changed := false
+	for i := range exportPolicy.Status.Sinks {
+		status := &exportPolicy.Status.Sinks[i]
+
+		limit, limited := limitedSinks[status.Name]
+		if !limited {
+			if apimeta.RemoveStatusCondition(&status.Conditions, "CardinalityLimited") {
+				changed = true
+			}
+			continue
+		}
+
+		condition := metav1.Condition{
+			Type:               "CardinalityLimited",
+			ObservedGeneration: exportPolicy.Generation,
+		}
+
+		componentID := getVectorComponentID(exportPolicy, projectName, status.Name+"-"+cardinalityLimitTransformName, vectorTransform)
+		exceededValues := exceeded[componentID]
+
+		switch _, configured := transforms[componentID]; {
+		case !r.MetricsService.QueryEnabled():
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "MetricsUnavailable"
+			condition.Message = "The cardinality of the sink can not be determined."
+		case !configured:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "NotConfigured"
+			condition.Message = "The cardinality limit is not configured. Check the Accepted condition for more details."
+		case queryErr != nil:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "MetricsQueryFailed"
+			condition.Message = "The cardinality of the sink could not be retrieved."
+		case exceededValues > 0:
+			dropped := "the labels were removed from the metrics"
+			if limit.Mode == v1alpha1.CardinalityLimitModeDropEvent {
+				dropped = "the metrics were dropped"
+			}
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "LimitExceeded"
+			condition.Message = fmt.Sprintf("Label values exceeded the limit of %d values per label over the last five minutes and %s.", limit.ValueLimit, dropped)
+		default:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "WithinLimit"
+			condition.Message = fmt.Sprintf("No labels have exceeded the limit of %d values per label over the last five minutes.", limit.ValueLimit)
+		}
+
+		if apimeta.SetStatusCondition(&status.Conditions, condition) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// cardinalityLimitExceeded returns the number of label values that exceeded
+// the cardinality limit of each sink over the last five minutes, keyed by the
+// vector component ID of the sink's tag_cardinality_limit transform.
+func (r *ExportPolicyReconciler) cardinalityLimitExceeded(ctx context.Context, exportPolicy *v1alpha1.ExportPolicy) (map[string]float64, error) {
+	samples, err := r.MetricsService.Query(ctx, fmt.Sprintf(`sum by (component_id) (increase(vector_tag_value_limit_exceeded_total{resource_uid=%q, component_kind="transform"}[5m]))`, exportPolicy.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	exceeded := map[string]float64{}
+	for _, sample := range samples {
+		exceeded[string(sample.Metric["component_id"])] = float64(sample.Value)
+	}
+	return exceeded, nil
+}
+
+// sinkMetricRates returns the per second rate of a counter reported by each
+// sink of the export policy over the last five minutes, keyed by the sink's
+// vector component ID. Additional label matchers can be provided to select
+// the series of the counter.
+func (r *ExportPolicyReconciler) sinkMetricRates(ctx context.Context, exportPolicy *v1alpha1.ExportPolicy, metric string, matchers ...string) (map[string]float64, error) {
+	matchers = append([]string{fmt.Sprintf("resource_uid=%q", exportPolicy.UID), `component_kind="sink"`}, matchers...)
+	samples, err := r.MetricsService.Query(ctx, fmt.Sprintf(`sum by (component_id) (rate(%s{%s}[5m]))`, metric, strings.Join(matchers, ", ")))
+	if err != nil {
+		return nil, err
+	}
+
+	rates := map[string]float64{}
+	for _, sample := range samples {
+		rates[string(sample.Metric["component_id"])] = float64(sample.Value)
+	}
+	return rates, nil
+}
+
+// formatRate formats a per second rate for the status of the export policy.
+// The rate is rounded to a single significant digit so small fluctuations
+// between refreshes don't cause the status to be updated.
+func formatRate(rate float64) string {
+	if rate <= 0 {
+		return "0"
+	}
+	exponent := math.Floor(math.Log10(rate))
+	magnitude := math.Pow(10, exponent)
+	return strconv.FormatFloat(math.Round(rate/magnitude)*magnitude, 'f', max(0, int(-exponent)), 64)
+}
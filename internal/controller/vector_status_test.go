@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.datum.net/telemetry-services-operator/api/v1alpha1"
+)
+
+// newMetricsServer creates a metrics service that responds to instant queries
+// with a sample for each of the label sets returned for the query. The value of
+// the sample is read from the `__value__` label.
+func newMetricsServer(t *testing.T, results func(query string) []map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		samples := []map[string]any{}
+		for _, result := range results(r.FormValue("query")) {
+			labels := maps.Clone(result)
+			delete(labels, "__value__")
+			samples = append(samples, map[string]any{
+				"metric": labels,
+				"value":  []any{float64(time.Now().Unix()), result["__value__"]},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data": map[string]any{
+				"resultType": "vector",
+				"result":     samples,
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReconcileProgrammedCondition(t *testing.T) {
+	exportPolicy := newExportPolicy()
+	exportPolicy.Generation = 2
+
+	sourceID := getVectorComponentID(exportPolicy, "test-project", "source", vectorSource)
+	sinkID := getVectorComponentID(exportPolicy, "test-project", "sink", vectorSink)
+	vectorConfig := map[string]any{
+		"sources": map[string]any{sourceID: map[string]any{}},
+		"sinks":   map[string]any{sinkID: map[string]any{}},
+	}
+
+	newConfigSecret := func(updatedAt time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					exportPolicyGenerationAnnotation:    "2",
+					exportPolicyConfigUpdatedAnnotation: updatedAt.Format(time.RFC3339),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		loadedComponents     []string
+		configSecret         *corev1.Secret
+		expectedStatus       metav1.ConditionStatus
+		expectedReason       string
+		expectedProgrammedAt int64
+	}{
+		{
+			name:                 "all components are loaded",
+			loadedComponents:     []string{sourceID, sinkID},
+			configSecret:         newConfigSecret(time.Now().Add(-time.Hour)),
+			expectedStatus:       metav1.ConditionTrue,
+			expectedReason:       "Programmed",
+			expectedProgrammedAt: 2,
+		},
+		{
+			name:             "some components are not loaded",
+			loadedComponents: []string{sourceID},
+			configSecret:     newConfigSecret(time.Now().Add(-time.Hour)),
+			expectedStatus:   metav1.ConditionFalse,
+			expectedReason:   "Pending",
+		},
+		{
+			name:             "configuration was recently updated",
+			loadedComponents: []string{sourceID, sinkID},
+			configSecret:     newConfigSecret(time.Now()),
+			expectedStatus:   metav1.ConditionFalse,
+			expectedReason:   "Pending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []map[string]string{}
+			for _, component := range tt.loadedComponents {
+				results = append(results, map[string]string{"component_id": component, "__value__": "1"})
+			}
+
+			reconciler := &ExportPolicyReconciler{
+				MetricsService: MetricsService{QueryEndpoint: newMetricsServer(t, func(string) []map[string]string { return results }).URL},
+			}
+
+			ep := exportPolicy.DeepCopy()
+			assert.True(t, reconciler.reconcileProgrammedCondition(context.Background(), ep, tt.configSecret, vectorConfig))
+
+			condition := apimeta.FindStatusCondition(ep.Status.Conditions, "Programmed")
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tt.expectedStatus, condition.Status)
+				assert.Equal(t, tt.expectedReason, condition.Reason)
+			}
+			assert.Equal(t, tt.expectedProgrammedAt, ep.Status.ProgrammedGeneration)
+		})
+	}
+}
+
+func TestReconcileSinkHealth(t *testing.T) {
+	exportPolicy := newExportPolicy()
+	exportPolicy.Status.Sinks = []v1alpha1.SinkStatus{{Name: "sink"}}
+
+	sinkID := getVectorComponentID(exportPolicy, "test-project", "sink", vectorSink)
+	vectorConfig := map[string]any{
+		"sinks": map[string]any{sinkID: map[string]any{}},
+	}
+
+	tests := []struct {
+		name                string
+		sentRate            string
+		errorRate           string
+		droppedRate         string
+		expectedStatus      metav1.ConditionStatus
+		expectedReason      string
+		expectedErrorRate   string
+		expectedDroppedRate string
+		expectLastSent      bool
+	}{
+		{
+			name:              "sink is sending without errors",
+			sentRate:          "10",
+			errorRate:         "0",
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    "Healthy",
+			expectedErrorRate: "0",
+			expectLastSent:    true,
+		},
+		{
+			name:              "sink is reporting errors",
+			sentRate:          "0",
+			errorRate:         "0.25",
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    "Degraded",
+			expectedErrorRate: "0.3",
+		},
+		{
+			name:                "sink is dropping events it could not deliver",
+			sentRate:            "5",
+			errorRate:           "0.1",
+			droppedRate:         "2",
+			expectedStatus:      metav1.ConditionFalse,
+			expectedReason:      "Degraded",
+			expectedErrorRate:   "0.1",
+			expectedDroppedRate: "2",
+			expectLastSent:      true,
+		},
+		{
+			name:           "sink has not reported any metrics",
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: "NoData",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMetricsServer(t, func(query string) []map[string]string {
+				value := tt.sentRate
+				if strings.Contains(query, "vector_component_errors_total") {
+					value = tt.errorRate
+				} else if strings.Contains(query, "vector_component_discarded_events_total") {
+					value = tt.droppedRate
+				}
+				if value == "" {
+					return nil
+				}
+				return []map[string]string{{"component_id": sinkID, "__value__": value}}
+			})
+
+			reconciler := &ExportPolicyReconciler{
+				MetricsService: MetricsService{QueryEndpoint: server.URL},
+			}
+
+			ep := exportPolicy.DeepCopy()
+			assert.True(t, reconciler.reconcileSinkHealth(context.Background(), "test-project", ep, vectorConfig))
+
+			status := ep.Status.Sinks[0]
+			condition := apimeta.FindStatusCondition(status.Conditions, "Healthy")
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tt.expectedStatus, condition.Status)
+				assert.Equal(t, tt.expectedReason, condition.Reason)
+			}
+			assert.Equal(t, tt.expectedErrorRate, status.ErrorRate)
+			assert.Equal(t, tt.expectedDroppedRate, status.DroppedEventRate)
+			assert.Equal(t, tt.expectLastSent, status.LastSentTime != nil)
+		})
+	}
+
+	t.Run("small rate changes don't update the status", func(t *testing.T) {
+		errorRate := "0.25"
+		server := newMetricsServer(t, func(query string) []map[string]string {
+			value := "5"
+			if strings.Contains(query, "vector_component_errors_total") {
+				value = errorRate
+			} else if strings.Contains(query, "vector_component_discarded_events_total") {
+				return nil
+			}
+			return []map[string]string{{"component_id": sinkID, "__value__": value}}
+		})
+
+		reconciler := &ExportPolicyReconciler{
+			MetricsService: MetricsService{QueryEndpoint: server.URL},
+		}
+
+		ep := exportPolicy.DeepCopy()
+		assert.True(t, reconciler.reconcileSinkHealth(context.Background(), "test-project", ep, vectorConfig))
+
+		errorRate = "0.271"
+		assert.False(t, reconciler.reconcileSinkHealth(context.Background(), "test-project", ep, vectorConfig))
+	})
+}
+
+func TestVectorConfigChanged(t *testing.T) {
+	config := func(strategy, token, endpoint string) []byte {
+		data, _ := json.Marshal(map[string]any{
+			"sinks": map[string]any{
+				"sink": map[string]any{
+					"type":     "prometheus_remote_write",
+					"endpoint": endpoint,
+					"auth":     map[string]any{"strategy": strategy, "token": token},
+				},
+			},
+		})
+		return data
+	}
+
+	assert.True(t, vectorConfigChanged(nil, config("bearer", "token-1", "https://a.example.com")))
+	assert.False(t, vectorConfigChanged(config("bearer", "token-1", "https://a.example.com"), config("bearer", "token-1", "https://a.example.com")))
+	// Refreshed access tokens don't change the components vector has loaded.
+	assert.False(t, vectorConfigChanged(config("bearer", "token-1", "https://a.example.com"), config("bearer", "token-2", "https://a.example.com")))
+	assert.True(t, vectorConfigChanged(config("bearer", "token-1", "https://a.example.com"), config("bearer", "token-2", "https://b.example.com")))
+	assert.True(t, vectorConfigChanged(config("other", "token-1", "https://a.example.com"), config("other", "token-2", "https://a.example.com")))
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := map[float64]string{
+		0:       "0",
+		0.00042: "0.0004",
+		0.25:    "0.3",
+		0.271:   "0.3",
+		1.4:     "1",
+		2:       "2",
+		96:      "100",
+		1234.5:  "1000",
+	}
+
+	for rate, expected := range tests {
+		assert.Equal(t, expected, formatRate(rate), "rate %v", rate)
+	}
+}
+
+func TestVectorStatusRefreshInterval(t *testing.T) {
+	assert.InDelta(t, vectorStatusMinRefreshInterval, vectorStatusRefreshInterval(1), float64(vectorStatusMinRefreshInterval)*0.1)
+
+	// 1000 policies at 5 policies per second are refreshed every 200 seconds.
+	interval := vectorStatusRefreshInterval(1000)
+	assert.GreaterOrEqual(t, interval, 200*time.Second)
+	assert.LessOrEqual(t, interval, 220*time.Second)
+}
+
+func TestReconcileSinkCardinality(t *testing.T) {
+	exportPolicy := newExportPolicy(func(ep *v1alpha1.ExportPolicy) {
+		ep.Spec.Sinks[0].CardinalityLimit = &v1alpha1.CardinalityLimit{ValueLimit: 100}
+	})
+	exportPolicy.Status.Sinks = []v1alpha1.SinkStatus{{Name: "sink"}}
+
+	limitID := getVectorComponentID(exportPolicy, "test-project", "sink-"+cardinalityLimitTransformName, vectorTransform)
+	vectorConfig := map[string]any{
+		"transforms": map[string]any{limitID: map[string]any{}},
+	}
+
+	tests := []struct {
+		name           string
+		exceeded       string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "label values exceeded the limit",
+			exceeded:       "12",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "LimitExceeded",
+		},
+		{
+			name:           "label values are within the limit",
+			exceeded:       "0",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "WithinLimit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMetricsServer(t, func(query string) []map[string]string {
+				return []map[string]string{{"component_id": limitID, "__value__": tt.exceeded}}
+			})
+
+			reconciler := &ExportPolicyReconciler{
+				MetricsService: MetricsService{QueryEndpoint: server.URL},
+			}
+
+			ep := exportPolicy.DeepCopy()
+			assert.True(t, reconciler.reconcileSinkCardinality(context.Background(), "test-project", ep, vectorConfig))
+
+			condition := apimeta.FindStatusCondition(ep.Status.Sinks[0].Conditions, "CardinalityLimited")
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tt.expectedStatus, condition.Status)
+				assert.Equal(t, tt.expectedReason, condition.Reason)
+			}
+		})
+	}
+
+	t.Run("condition is removed when the sink has no limit", func(t *testing.T) {
+		ep := exportPolicy.DeepCopy()
+		ep.Spec.Sinks[0].CardinalityLimit = nil
+		ep.Status.Sinks[0].Conditions = []metav1.Condition{{Type: "CardinalityLimited", Status: metav1.ConditionTrue}}
+
+		reconciler := &ExportPolicyReconciler{}
+		assert.True(t, reconciler.reconcileSinkCardinality(context.Background(), "test-project", ep, vectorConfig))
+		assert.Empty(t, ep.Status.Sinks[0].Conditions)
+	})
+}
@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"net/netip"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, which is commonly used by
+// cluster networks.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddress returns whether the address can be reached by the control
+// plane on behalf of a tenant. Private, loopback, link-local, multicast and
+// unspecified addresses are rejected so tenants can't use the control plane
+// to reach internal services.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!sharedAddressSpace.Contains(addr)
+}
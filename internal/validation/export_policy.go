@@ -2,10 +2,18 @@ package validation
 
 import (
 	"fmt"
+	"math"
+	"net"
+	"net/netip"
 	"net/url"
+	"regexp"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/VictoriaMetrics/metricsql"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	telemetryv1alpha1 "go.datum.net/telemetry-services-operator/api/v1alpha1"
@@ -20,13 +28,25 @@ func ValidateExportPolicy(policy *telemetryv1alpha1.ExportPolicy) field.ErrorLis
 	return validateExportPolicySpec(field.NewPath("spec"), policy.Spec)
 }
 
+// ExportPolicyWarnings returns warnings for export policy configurations that
+// are valid but likely a mistake, such as sources that aren't used by any sinks.
+func ExportPolicyWarnings(policy *telemetryv1alpha1.ExportPolicy) []string {
+	var warnings []string
+	sourcesPath := field.NewPath("spec", "sources")
+	for index, source := range policy.Spec.Sources {
+		if !slices.ContainsFunc(policy.Spec.Sinks, func(sink telemetryv1alpha1.TelemetrySink) bool { return slices.Contains(sink.Sources, source.Name) }) {
+			warnings = append(warnings, fmt.Sprintf("%s: source '%s' is not used by any sinks", sourcesPath.Index(index), source.Name))
+		}
+	}
+	return warnings
+}
+
 func validateExportPolicySpec(fieldPath *field.Path, spec telemetryv1alpha1.ExportPolicySpec) field.ErrorList {
 	var errs field.ErrorList
+	sourceNames := map[string]telemetryv1alpha1.TelemetrySource{}
 	if len(spec.Sources) == 0 {
 		errs = append(errs, field.Required(fieldPath.Child("sources"), "At least one telemetry source is required"))
 	} else {
-		sourceNames := map[string]struct{}{}
-
 		for index, source := range spec.Sources {
 			sourcePath := fieldPath.Child("sources").Index(index)
 			if source.Name == "" {
@@ -34,18 +54,38 @@ func validateExportPolicySpec(fieldPath *field.Path, spec telemetryv1alpha1.Expo
 			} else if _, set := sourceNames[source.Name]; set {
 				errs = append(errs, field.Duplicate(sourcePath.Child("name"), source.Name))
 			} else {
-				sourceNames[source.Name] = struct{}{}
+				sourceNames[source.Name] = source
 			}
 
-			if source.Metrics == nil {
-				errs = append(errs, field.Required(sourcePath.Child("metrics"), "A source must provide a metrics source. Additional source types will be supported in the future."))
-			} else {
+			var signals []string
+			for signal, configured := range map[string]bool{SignalMetrics: source.Metrics != nil, SignalLogs: source.Logs != nil, SignalTraces: source.Traces != nil} {
+				if configured {
+					signals = append(signals, signal)
+				}
+			}
+			if len(signals) == 0 {
+				errs = append(errs, field.Required(sourcePath, "A source must provide a metrics, logs or traces source"))
+			} else if len(signals) > 1 {
+				slices.Sort(signals)
+				errs = append(errs, field.Forbidden(sourcePath, fmt.Sprintf("Only one of metrics, logs or traces can be configured for a source, found: %s", strings.Join(signals, ", "))))
+			}
+
+			if source.Metrics != nil {
 				errs = append(errs, validateMetricSource(sourcePath.Child("metrics"), *source.Metrics)...)
 			}
+
+			if source.Logs != nil {
+				errs = append(errs, validateLogSource(sourcePath.Child("logs"), *source.Logs)...)
+			}
+
+			if source.Traces != nil {
+				errs = append(errs, validateTraceSource(sourcePath.Child("traces"), *source.Traces)...)
+			}
 		}
 	}
 
 	sinkNames := map[string]struct{}{}
+	var diskBufferSize int64
 	for index, sink := range spec.Sinks {
 		// Validate that the sink name is unique
 		sinkPath := fieldPath.Child("sinks").Index(index)
@@ -55,7 +95,42 @@ func validateExportPolicySpec(fieldPath *field.Path, spec telemetryv1alpha1.Expo
 			sinkNames[sink.Name] = struct{}{}
 		}
 
-		errs = append(errs, validateTelemetrySink(sinkPath, sink)...)
+		// Validate that the sink only references sources that are defined in the
+		// export policy and that all of the sources produce the same signal.
+		sinkSources := map[string]struct{}{}
+		sinkSignal := ""
+		for sourceIndex, source := range sink.Sources {
+			sourcePath := sinkPath.Child("sources").Index(sourceIndex)
+			if _, set := sinkSources[source]; set {
+				errs = append(errs, field.Duplicate(sourcePath, source))
+			} else if definedSource, defined := sourceNames[source]; !defined {
+				errs = append(errs, field.NotFound(sourcePath, source))
+			} else if signal := SourceSignal(definedSource); signal != "" && sink.Target != nil && !slices.Contains(SinkTargetSignals(*sink.Target), signal) {
+				errs = append(errs, field.Invalid(sourcePath, source, fmt.Sprintf("The sink target does not support %s sources", signal)))
+			} else if sinkSignal != "" && signal != sinkSignal {
+				errs = append(errs, field.Invalid(sourcePath, source, fmt.Sprintf("A sink can only publish one telemetry signal, the sink's other sources produce %s", sinkSignal)))
+			} else {
+				sinkSignal = signal
+			}
+			sinkSources[source] = struct{}{}
+		}
+
+		if sink.Transforms != nil && sinkSignal != "" && sinkSignal != SignalMetrics {
+			errs = append(errs, field.Forbidden(sinkPath.Child("transforms"), fmt.Sprintf("Transforms are only supported for sinks that publish metrics, the sink's sources produce %s", sinkSignal)))
+		}
+
+		if sink.CardinalityLimit != nil && sinkSignal != "" && sinkSignal != SignalMetrics {
+			errs = append(errs, field.Forbidden(sinkPath.Child("cardinalityLimit"), fmt.Sprintf("A cardinality limit is only supported for sinks that publish metrics, the sink's sources produce %s", sinkSignal)))
+		}
+
+		if size := DiskBufferSize(sink); size > 0 {
+			diskBufferSize += size
+			if diskBufferSize > MaxExportPolicyDiskBufferSize.Value() {
+				errs = append(errs, field.Invalid(sinkPath.Child("buffer", "maxSize"), sink.Buffer.MaxSize.String(), "The disk buffers of an export policy's sinks can not be larger than "+MaxExportPolicyDiskBufferSize.String()+" in total"))
+			}
+		}
+
+		errs = append(errs, validateTelemetrySink(sinkPath, sink, sinkSignal)...)
 	}
 
 	return errs
@@ -63,14 +138,20 @@ func validateExportPolicySpec(fieldPath *field.Path, spec telemetryv1alpha1.Expo
 
 func validateMetricSource(path *field.Path, metrics telemetryv1alpha1.MetricSource) field.ErrorList {
 	var errs field.ErrorList
-	if metrics.MetricsQL == "" {
-		errs = append(errs, field.Required(path.Child("metricsql"), "A metricsql query is required. Additional metric options will be supported in the future."))
+	if metrics.MetricsQL != "" && metrics.Selector != nil {
+		errs = append(errs, field.Forbidden(path, "Only one of metricsql or selector can be configured"))
+	}
+
+	if metrics.Selector != nil {
+		errs = append(errs, validateMetricSelector(path.Child("selector"), *metrics.Selector)...)
+	} else if metrics.MetricsQL == "" {
+		errs = append(errs, field.Required(path.Child("metricsql"), "A metricsql query or selector is required"))
 	} else {
 		expr, err := metricsql.Parse(metrics.MetricsQL)
 		if err != nil {
 			errs = append(errs, field.Invalid(path.Child("metricsql"), metrics.MetricsQL, fmt.Sprintf("Invalid metricsql query provided: %s", err)))
 		} else if metricExpr, ok := expr.(*metricsql.MetricExpr); !ok {
-			errs = append(errs, field.Invalid(path.Child("metricsql"), metrics.MetricsQL, `Only metrics queries in the format '{label="value"}' are supported`))
+			errs = append(errs, field.Invalid(path.Child("metricsql"), metrics.MetricsQL, `Only metrics queries in the format '{label="value"}' are supported, use the aggregation option to aggregate metrics`))
 		} else {
 			for _, labelFilters := range metricExpr.LabelFilterss {
 				for _, labelFilter := range labelFilters {
@@ -82,23 +163,368 @@ func validateMetricSource(path *field.Path, metrics telemetryv1alpha1.MetricSour
 		}
 	}
 
+	if metrics.Aggregation != nil {
+		errs = append(errs, validateMetricAggregation(path.Child("aggregation"), *metrics.Aggregation)...)
+	}
+
 	return errs
 }
 
-func validateTelemetrySink(path *field.Path, sink telemetryv1alpha1.TelemetrySink) field.ErrorList {
+var supportedMetricAggregationFunctions = []telemetryv1alpha1.MetricAggregationFunction{
+	telemetryv1alpha1.MetricAggregationFunctionIncrease,
+	telemetryv1alpha1.MetricAggregationFunctionSum,
+	telemetryv1alpha1.MetricAggregationFunctionAvg,
+	telemetryv1alpha1.MetricAggregationFunctionMax,
+}
+
+func validateMetricAggregation(path *field.Path, aggregation telemetryv1alpha1.MetricAggregation) field.ErrorList {
 	var errs field.ErrorList
-	errs = append(errs, validateTelemetrySinkTarget(path.Child("target"), *sink.Target)...)
+	if aggregation.Function == "" {
+		errs = append(errs, field.Required(path.Child("function"), "An aggregation function is required"))
+	} else if !slices.Contains(supportedMetricAggregationFunctions, aggregation.Function) {
+		errs = append(errs, field.NotSupported(path.Child("function"), aggregation.Function, supportedMetricAggregationFunctions))
+	}
+
+	// A zero window uses the default window.
+	if window := aggregation.Window.Duration; window != 0 && (window < 30*time.Second || window > time.Hour || window%time.Second != 0) {
+		errs = append(errs, field.Invalid(path.Child("window"), window.String(), "The window must be a whole number of seconds between 30s and 1h"))
+	}
+
+	for index, label := range aggregation.By {
+		if !LabelNamePattern.MatchString(label) {
+			errs = append(errs, field.Invalid(path.Child("by").Index(index), label, "Label names must match the pattern "+LabelNamePattern.String()))
+		}
+	}
 	return errs
 }
 
-func validateTelemetrySinkTarget(path *field.Path, sink telemetryv1alpha1.SinkTarget) field.ErrorList {
+var supportedLabelMatchOperators = []telemetryv1alpha1.LabelMatchOperator{
+	telemetryv1alpha1.LabelMatchOperatorEqual,
+	telemetryv1alpha1.LabelMatchOperatorNotEqual,
+	telemetryv1alpha1.LabelMatchOperatorRegexMatch,
+	telemetryv1alpha1.LabelMatchOperatorNotRegexMatch,
+}
+
+func validateMetricSelector(path *field.Path, selector telemetryv1alpha1.MetricSelector) field.ErrorList {
 	var errs field.ErrorList
-	if sink.PrometheusRemoteWrite == nil {
-		errs = append(errs, field.Required(path.Child("prometheusRemoteWrite"), ""))
-	} else {
+	for name, values := range map[string][]string{
+		"resourceKinds":      selector.ResourceKinds,
+		"resourceNames":      selector.ResourceNames,
+		"resourceNamespaces": selector.ResourceNamespaces,
+		"metricNames":        selector.MetricNames,
+	} {
+		for index, value := range values {
+			if value == "" {
+				errs = append(errs, field.Required(path.Child(name).Index(index), "A value is required"))
+			}
+		}
+	}
+
+	for index, matcher := range selector.LabelMatchers {
+		matcherPath := path.Child("labelMatchers").Index(index)
+		if matcher.Label == "" {
+			errs = append(errs, field.Required(matcherPath.Child("label"), "A label name is required"))
+		} else if slices.Contains(forbiddenLabels, matcher.Label) {
+			errs = append(errs, field.Forbidden(matcherPath.Child("label"), fmt.Sprintf("The label '%s' can not be matched", matcher.Label)))
+		}
+
+		switch matcher.Operator {
+		case telemetryv1alpha1.LabelMatchOperatorRegexMatch, telemetryv1alpha1.LabelMatchOperatorNotRegexMatch:
+			if _, err := regexp.Compile(matcher.Value); err != nil {
+				errs = append(errs, field.Invalid(matcherPath.Child("value"), matcher.Value, fmt.Sprintf("Invalid regular expression: %s", err)))
+			}
+		case telemetryv1alpha1.LabelMatchOperatorEqual, telemetryv1alpha1.LabelMatchOperatorNotEqual:
+		default:
+			errs = append(errs, field.NotSupported(matcherPath.Child("operator"), matcher.Operator, supportedLabelMatchOperators))
+		}
+	}
+	return errs
+}
+
+func validateLogSource(path *field.Path, logs telemetryv1alpha1.LogSource) field.ErrorList {
+	var errs field.ErrorList
+	if logs.LogsQL == "" {
+		errs = append(errs, field.Required(path.Child("logsql"), "A LogsQL filter is required"))
+	} else if err := CheckLogsQLFilter(logs.LogsQL); err != nil {
+		errs = append(errs, field.Invalid(path.Child("logsql"), logs.LogsQL, fmt.Sprintf("Invalid LogsQL filter provided: %s", err)))
+	}
+	return errs
+}
+
+// TraceProjectAttribute is the resource attribute that identifies the project
+// that a span belongs to. It's set by the platform's trace collector, which is
+// the only client that's allowed to send spans to the platform trace receiver,
+// so sources can't select it.
+const TraceProjectAttribute = "resourcemanager.datumapis.com/project-name"
+
+func validateTraceSource(path *field.Path, traces telemetryv1alpha1.TraceSource) field.ErrorList {
+	var errs field.ErrorList
+	serviceNames := map[string]struct{}{}
+	for index, serviceName := range traces.ServiceNames {
+		if serviceName == "" {
+			errs = append(errs, field.Required(path.Child("serviceNames").Index(index), "A service name is required"))
+		} else if _, set := serviceNames[serviceName]; set {
+			errs = append(errs, field.Duplicate(path.Child("serviceNames").Index(index), serviceName))
+		}
+		serviceNames[serviceName] = struct{}{}
+	}
+
+	attributeKeys := map[string]struct{}{}
+	for index, attribute := range traces.ResourceAttributes {
+		attributePath := path.Child("resourceAttributes").Index(index)
+		if attribute.Key == "" {
+			errs = append(errs, field.Required(attributePath.Child("key"), "A resource attribute key is required"))
+		} else if attribute.Key == TraceProjectAttribute {
+			errs = append(errs, field.Forbidden(attributePath.Child("key"), fmt.Sprintf("The resource attribute '%s' can not be selected", attribute.Key)))
+		} else if _, set := attributeKeys[attribute.Key]; set {
+			errs = append(errs, field.Duplicate(attributePath.Child("key"), attribute.Key))
+		}
+		attributeKeys[attribute.Key] = struct{}{}
+	}
+	return errs
+}
+
+func validateTelemetrySink(path *field.Path, sink telemetryv1alpha1.TelemetrySink, signal string) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTelemetrySinkTarget(path.Child("target"), *sink.Target, signal)...)
+	if sink.Transforms != nil {
+		errs = append(errs, validateSinkTransforms(path.Child("transforms"), *sink.Transforms)...)
+	}
+	if sink.CardinalityLimit != nil {
+		errs = append(errs, validateCardinalityLimit(path.Child("cardinalityLimit"), *sink.CardinalityLimit)...)
+	}
+	if sink.Buffer != nil {
+		errs = append(errs, validateSinkBuffer(path.Child("buffer"), *sink.Buffer)...)
+	}
+	return errs
+}
+
+var (
+	// MinDiskBufferSize is the minimum size of a disk buffer.
+	MinDiskBufferSize = resource.MustParse("256Mi")
+
+	// MaxDiskBufferSize is the maximum size of a sink's disk buffer. The disk
+	// buffers of every export policy are stored on the same volume, so a single
+	// sink can't be allowed to fill it.
+	MaxDiskBufferSize = resource.MustParse("2Gi")
+
+	// MaxExportPolicyDiskBufferSize is the maximum total size of the disk
+	// buffers of an export policy's sinks.
+	MaxExportPolicyDiskBufferSize = resource.MustParse("4Gi")
+)
+
+// DiskBufferSize returns the max size of the sink's disk buffer in bytes, or
+// zero if the sink doesn't use a disk buffer.
+func DiskBufferSize(sink telemetryv1alpha1.TelemetrySink) int64 {
+	if sink.Buffer == nil || sink.Buffer.Type != telemetryv1alpha1.SinkBufferTypeDisk || sink.Buffer.MaxSize == nil {
+		return 0
+	}
+	return sink.Buffer.MaxSize.Value()
+}
+
+var supportedSinkBufferTypes = []telemetryv1alpha1.SinkBufferType{
+	telemetryv1alpha1.SinkBufferTypeMemory,
+	telemetryv1alpha1.SinkBufferTypeDisk,
+}
+
+var supportedSinkBufferWhenFull = []telemetryv1alpha1.SinkBufferWhenFull{
+	telemetryv1alpha1.SinkBufferWhenFullBlock,
+	telemetryv1alpha1.SinkBufferWhenFullDropNewest,
+}
+
+func validateSinkBuffer(path *field.Path, buffer telemetryv1alpha1.SinkBuffer) field.ErrorList {
+	var errs field.ErrorList
+	switch buffer.Type {
+	case telemetryv1alpha1.SinkBufferTypeMemory, "":
+		if buffer.MaxSize != nil {
+			errs = append(errs, field.Forbidden(path.Child("maxSize"), "A max size is only supported by disk buffers, use maxEvents for memory buffers"))
+		}
+		if buffer.MaxEvents < 0 {
+			errs = append(errs, field.Invalid(path.Child("maxEvents"), buffer.MaxEvents, "The max events must be at least 1"))
+		}
+	case telemetryv1alpha1.SinkBufferTypeDisk:
+		if buffer.MaxEvents != 0 {
+			errs = append(errs, field.Forbidden(path.Child("maxEvents"), "Max events is only supported by memory buffers, use maxSize for disk buffers"))
+		}
+		if buffer.MaxSize == nil {
+			errs = append(errs, field.Required(path.Child("maxSize"), "A max size is required for disk buffers"))
+		} else if buffer.MaxSize.Cmp(MinDiskBufferSize) < 0 {
+			errs = append(errs, field.Invalid(path.Child("maxSize"), buffer.MaxSize.String(), "The max size of a disk buffer must be at least "+MinDiskBufferSize.String()))
+		} else if buffer.MaxSize.Cmp(MaxDiskBufferSize) > 0 {
+			errs = append(errs, field.Invalid(path.Child("maxSize"), buffer.MaxSize.String(), "The max size of a disk buffer can not be larger than "+MaxDiskBufferSize.String()))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), buffer.Type, supportedSinkBufferTypes))
+	}
+
+	if buffer.WhenFull != "" && !slices.Contains(supportedSinkBufferWhenFull, buffer.WhenFull) {
+		errs = append(errs, field.NotSupported(path.Child("whenFull"), buffer.WhenFull, supportedSinkBufferWhenFull))
+	}
+	return errs
+}
+
+var supportedCardinalityLimitModes = []telemetryv1alpha1.CardinalityLimitMode{
+	telemetryv1alpha1.CardinalityLimitModeDropTag,
+	telemetryv1alpha1.CardinalityLimitModeDropEvent,
+}
+
+func validateCardinalityLimit(path *field.Path, limit telemetryv1alpha1.CardinalityLimit) field.ErrorList {
+	var errs field.ErrorList
+	if limit.ValueLimit < 1 {
+		errs = append(errs, field.Invalid(path.Child("valueLimit"), limit.ValueLimit, "The value limit must be at least 1"))
+	}
+
+	if limit.Mode != "" && !slices.Contains(supportedCardinalityLimitModes, limit.Mode) {
+		errs = append(errs, field.NotSupported(path.Child("mode"), limit.Mode, supportedCardinalityLimitModes))
+	}
+	return errs
+}
+
+func validateSinkTransforms(path *field.Path, transforms telemetryv1alpha1.SinkTransforms) field.ErrorList {
+	var errs field.ErrorList
+	for name, patterns := range map[string][]string{"includeMetrics": transforms.IncludeMetrics, "excludeMetrics": transforms.ExcludeMetrics} {
+		for index, pattern := range patterns {
+			if pattern == "" {
+				errs = append(errs, field.Required(path.Child(name).Index(index), "A regular expression is required"))
+			} else if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, field.Invalid(path.Child(name).Index(index), pattern, fmt.Sprintf("Invalid regular expression: %s", err)))
+			}
+		}
+	}
+
+	if len(transforms.DropLabels) > 0 && len(transforms.KeepLabels) > 0 {
+		errs = append(errs, field.Forbidden(path, "Only one of dropLabels or keepLabels can be configured"))
+	}
+
+	for name, labels := range map[string][]string{"dropLabels": transforms.DropLabels, "keepLabels": transforms.KeepLabels} {
+		for index, label := range labels {
+			if !LabelNamePattern.MatchString(label) {
+				errs = append(errs, field.Invalid(path.Child(name).Index(index), label, "Label names must match the pattern "+LabelNamePattern.String()))
+			}
+		}
+	}
+
+	labelNames := map[string]struct{}{}
+	for index, label := range transforms.AddLabels {
+		labelPath := path.Child("addLabels").Index(index)
+		if !LabelNamePattern.MatchString(label.Name) {
+			errs = append(errs, field.Invalid(labelPath.Child("name"), label.Name, "Label names must match the pattern "+LabelNamePattern.String()))
+		} else if _, set := labelNames[label.Name]; set {
+			errs = append(errs, field.Duplicate(labelPath.Child("name"), label.Name))
+		}
+		labelNames[label.Name] = struct{}{}
+	}
+
+	for index, relabelConfig := range transforms.RelabelConfigs {
+		errs = append(errs, validateRelabelConfig(path.Child("relabelConfigs").Index(index), relabelConfig)...)
+	}
+	return errs
+}
+
+var supportedRelabelActions = []telemetryv1alpha1.RelabelAction{
+	telemetryv1alpha1.RelabelActionReplace,
+	telemetryv1alpha1.RelabelActionKeep,
+	telemetryv1alpha1.RelabelActionDrop,
+	telemetryv1alpha1.RelabelActionLabelMap,
+	telemetryv1alpha1.RelabelActionLabelDrop,
+	telemetryv1alpha1.RelabelActionLabelKeep,
+	telemetryv1alpha1.RelabelActionHashMod,
+}
+
+func validateRelabelConfig(path *field.Path, relabelConfig telemetryv1alpha1.RelabelConfig) field.ErrorList {
+	var errs field.ErrorList
+	for index, label := range relabelConfig.SourceLabels {
+		if !LabelNamePattern.MatchString(label) {
+			errs = append(errs, field.Invalid(path.Child("sourceLabels").Index(index), label, "Label names must match the pattern "+LabelNamePattern.String()))
+		}
+	}
+
+	if _, err := regexp.Compile(relabelConfig.Regex); err != nil {
+		errs = append(errs, field.Invalid(path.Child("regex"), relabelConfig.Regex, fmt.Sprintf("Invalid regular expression: %s", err)))
+	}
+
+	if relabelConfig.TargetLabel != "" && !LabelNamePattern.MatchString(relabelConfig.TargetLabel) {
+		errs = append(errs, field.Invalid(path.Child("targetLabel"), relabelConfig.TargetLabel, "Label names must match the pattern "+LabelNamePattern.String()))
+	}
+
+	switch relabelConfig.Action {
+	case telemetryv1alpha1.RelabelActionReplace, "":
+		if relabelConfig.TargetLabel == "" {
+			errs = append(errs, field.Required(path.Child("targetLabel"), "A target label is required for the replace action"))
+		}
+	case telemetryv1alpha1.RelabelActionHashMod:
+		if relabelConfig.TargetLabel == "" {
+			errs = append(errs, field.Required(path.Child("targetLabel"), "A target label is required for the hashmod action"))
+		}
+		if relabelConfig.Modulus < 1 || relabelConfig.Modulus > math.MaxInt32 {
+			errs = append(errs, field.Invalid(path.Child("modulus"), relabelConfig.Modulus, fmt.Sprintf("The modulus must be between 1 and %d", math.MaxInt32)))
+		}
+	case telemetryv1alpha1.RelabelActionKeep, telemetryv1alpha1.RelabelActionDrop:
+		if len(relabelConfig.SourceLabels) == 0 {
+			errs = append(errs, field.Required(path.Child("sourceLabels"), fmt.Sprintf("Source labels are required for the %s action", relabelConfig.Action)))
+		}
+	case telemetryv1alpha1.RelabelActionLabelMap, telemetryv1alpha1.RelabelActionLabelDrop, telemetryv1alpha1.RelabelActionLabelKeep:
+		if len(relabelConfig.SourceLabels) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("sourceLabels"), fmt.Sprintf("Source labels are not supported by the %s action", relabelConfig.Action)))
+		}
+		if relabelConfig.TargetLabel != "" {
+			errs = append(errs, field.Forbidden(path.Child("targetLabel"), fmt.Sprintf("A target label is not supported by the %s action", relabelConfig.Action)))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("action"), relabelConfig.Action, supportedRelabelActions))
+	}
+	return errs
+}
+
+func validateTelemetrySinkTarget(path *field.Path, sink telemetryv1alpha1.SinkTarget, signal string) field.ErrorList {
+	var errs field.ErrorList
+	var targets []string
+	if sink.PrometheusRemoteWrite != nil {
+		targets = append(targets, "prometheusRemoteWrite")
 		errs = append(errs, validatePrometheusRemoteWrite(path.Child("prometheusRemoteWrite"), *sink.PrometheusRemoteWrite)...)
 	}
 
+	if sink.OpenTelemetry != nil {
+		targets = append(targets, "openTelemetry")
+		errs = append(errs, validateOpenTelemetry(path.Child("openTelemetry"), *sink.OpenTelemetry)...)
+	}
+
+	if sink.DatadogMetrics != nil {
+		targets = append(targets, "datadogMetrics")
+		errs = append(errs, validateDatadogMetrics(path.Child("datadogMetrics"), *sink.DatadogMetrics)...)
+	}
+
+	if sink.Loki != nil {
+		targets = append(targets, "loki")
+		errs = append(errs, validateLoki(path.Child("loki"), *sink.Loki)...)
+	}
+
+	if sink.ObjectStorage != nil {
+		targets = append(targets, "objectStorage")
+		errs = append(errs, validateObjectStorage(path.Child("objectStorage"), *sink.ObjectStorage, signal)...)
+	}
+
+	if sink.Kafka != nil {
+		targets = append(targets, "kafka")
+		errs = append(errs, validateKafka(path.Child("kafka"), *sink.Kafka, signal)...)
+	}
+
+	if sink.SplunkHEC != nil {
+		targets = append(targets, "splunkHec")
+		errs = append(errs, validateSplunkHEC(path.Child("splunkHec"), *sink.SplunkHEC)...)
+	}
+
+	if sink.Elasticsearch != nil {
+		targets = append(targets, "elasticsearch")
+		errs = append(errs, validateElasticsearch(path.Child("elasticsearch"), *sink.Elasticsearch)...)
+	}
+
+	if len(targets) == 0 {
+		errs = append(errs, field.Required(path, "A sink target must be configured"))
+	} else if len(targets) > 1 {
+		errs = append(errs, field.Forbidden(path, fmt.Sprintf("Only one sink target can be configured, found: %s", strings.Join(targets, ", "))))
+	}
+
 	return errs
 }
 
@@ -109,5 +535,537 @@ func validatePrometheusRemoteWrite(path *field.Path, otel telemetryv1alpha1.Prom
 	} else if _, err := url.Parse(otel.Endpoint); err != nil {
 		errs = append(errs, field.Invalid(path.Child("http"), otel.Endpoint, fmt.Sprintf("Failed to parse URL: %s", err)))
 	}
+
+	if otel.Authentication != nil {
+		errs = append(errs, validateAuthentication(path.Child("authentication"), *otel.Authentication)...)
+	}
+
+	if otel.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *otel.TLS)...)
+	}
+
+	errs = append(errs, validateHTTPHeaders(path.Child("headers"), otel.Headers)...)
+	errs = append(errs, validateBatch(path.Child("batch"), otel.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), otel.Retry)...)
+	return errs
+}
+
+func validateOpenTelemetry(path *field.Path, otel telemetryv1alpha1.OpenTelemetrySink) field.ErrorList {
+	var errs field.ErrorList
+	if otel.HTTP == nil {
+		errs = append(errs, field.Required(path.Child("http"), "The OTLP HTTP transport must be configured"))
+	} else {
+		errs = append(errs, validateOpenTelemetryHTTP(path.Child("http"), *otel.HTTP)...)
+	}
+	return errs
+}
+
+func validateOpenTelemetryHTTP(path *field.Path, otel telemetryv1alpha1.OpenTelemetryHTTPSink) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateHTTPEndpoint(path.Child("endpoint"), otel.Endpoint)...)
+
+	if otel.Authentication != nil && otel.Authentication.AWSSigV4 != nil {
+		errs = append(errs, field.Forbidden(path.Child("authentication", "awsSigV4"), "AWS SigV4 authentication is only supported by Prometheus Remote Write sinks"))
+	}
+
+	switch otel.Encoding {
+	case telemetryv1alpha1.OpenTelemetryEncodingProtobuf:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("encoding"), otel.Encoding, []telemetryv1alpha1.OpenTelemetryEncoding{
+			telemetryv1alpha1.OpenTelemetryEncodingProtobuf,
+		}))
+	}
+
+	if otel.Authentication != nil {
+		errs = append(errs, validateAuthentication(path.Child("authentication"), *otel.Authentication)...)
+	}
+
+	if otel.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *otel.TLS)...)
+	}
+
+	errs = append(errs, validateHTTPHeaders(path.Child("headers"), otel.Headers)...)
+	errs = append(errs, validateBatch(path.Child("batch"), otel.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), otel.Retry)...)
+	return errs
+}
+
+func validateLoki(path *field.Path, loki telemetryv1alpha1.LokiSink) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateHTTPEndpoint(path.Child("endpoint"), loki.Endpoint)...)
+
+	if loki.Authentication != nil {
+		if loki.Authentication.AWSSigV4 != nil {
+			errs = append(errs, field.Forbidden(path.Child("authentication", "awsSigV4"), "AWS SigV4 authentication is only supported by Prometheus Remote Write sinks"))
+		}
+		errs = append(errs, validateAuthentication(path.Child("authentication"), *loki.Authentication)...)
+	}
+
+	if err := checkTemplate(loki.TenantID); err != nil {
+		errs = append(errs, field.Invalid(path.Child("tenantID"), loki.TenantID, err.Error()))
+	}
+
+	if len(loki.Labels) == 0 {
+		errs = append(errs, field.Required(path.Child("labels"), "At least one label is required"))
+	}
+	labelNames := map[string]struct{}{}
+	for index, label := range loki.Labels {
+		labelPath := path.Child("labels").Index(index)
+		if !LabelNamePattern.MatchString(label.Name) {
+			errs = append(errs, field.Invalid(labelPath.Child("name"), label.Name, "Label names must match the pattern "+LabelNamePattern.String()))
+		} else if _, set := labelNames[label.Name]; set {
+			errs = append(errs, field.Duplicate(labelPath.Child("name"), label.Name))
+		}
+		labelNames[label.Name] = struct{}{}
+
+		if label.Value == "" {
+			errs = append(errs, field.Required(labelPath.Child("value"), "A label value is required"))
+		} else if err := checkTemplate(label.Value); err != nil {
+			errs = append(errs, field.Invalid(labelPath.Child("value"), label.Value, err.Error()))
+		}
+	}
+
+	if loki.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *loki.TLS)...)
+	}
+
+	errs = append(errs, validateHTTPHeaders(path.Child("headers"), loki.Headers)...)
+	errs = append(errs, validateBatch(path.Child("batch"), loki.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), loki.Retry)...)
+	return errs
+}
+
+// LabelNamePattern matches valid metric label names.
+var LabelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// checkTemplate confirms every template in the value is closed so the value
+// can be used as a vector template.
+func checkTemplate(value string) error {
+	for remaining := value; ; {
+		start := strings.Index(remaining, "{{")
+		end := strings.Index(remaining, "}}")
+		switch {
+		case start == -1 && end == -1:
+			return nil
+		case start == -1 || (end != -1 && end < start):
+			return fmt.Errorf("template is missing an opening '{{'")
+		case end == -1:
+			return fmt.Errorf("template is missing a closing '}}'")
+		}
+		remaining = remaining[end+2:]
+	}
+}
+
+// metricTemplateFields are the fields of metric events that templates can
+// reference besides the metric's labels.
+var metricTemplateFields = []string{"name", "namespace"}
+
+// CheckMetricTemplate confirms every template in the value references a field
+// of metric events. The labels of a metric are stored in its tags, so templates
+// that reference a label as a top-level field never render.
+func CheckMetricTemplate(value string) error {
+	for remaining := value; ; {
+		start := strings.Index(remaining, "{{")
+		if start == -1 {
+			return nil
+		}
+		end := strings.Index(remaining[start:], "}}")
+		if end == -1 {
+			return nil
+		}
+		name := strings.TrimSpace(remaining[start+2 : start+end])
+		if !strings.HasPrefix(name, "tags.") && !slices.Contains(metricTemplateFields, name) {
+			return fmt.Errorf("metric labels must be referenced as tags (e.g. {{ tags.%s }})", name)
+		}
+		remaining = remaining[start+end+2:]
+	}
+}
+
+var supportedObjectStorageCompressions = []telemetryv1alpha1.ObjectStorageCompression{
+	telemetryv1alpha1.ObjectStorageCompressionNone,
+	telemetryv1alpha1.ObjectStorageCompressionGzip,
+	telemetryv1alpha1.ObjectStorageCompressionZstd,
+}
+
+func validateObjectStorage(path *field.Path, objectStorage telemetryv1alpha1.ObjectStorageSink, signal string) field.ErrorList {
+	var errs field.ErrorList
+	if objectStorage.Bucket == "" {
+		errs = append(errs, field.Required(path.Child("bucket"), "A bucket name is required"))
+	}
+
+	if err := checkTemplate(objectStorage.KeyPrefix); err != nil {
+		errs = append(errs, field.Invalid(path.Child("keyPrefix"), objectStorage.KeyPrefix, err.Error()))
+	} else if signal == SignalMetrics {
+		if err := CheckMetricTemplate(objectStorage.KeyPrefix); err != nil {
+			errs = append(errs, field.Invalid(path.Child("keyPrefix"), objectStorage.KeyPrefix, err.Error()))
+		}
+	}
+
+	if objectStorage.Region == "" {
+		errs = append(errs, field.Required(path.Child("region"), "The region of the bucket is required"))
+	}
+
+	if objectStorage.Endpoint != "" {
+		errs = append(errs, validateHTTPEndpoint(path.Child("endpoint"), objectStorage.Endpoint)...)
+	}
+
+	if objectStorage.SecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), "The name of the secret is required"))
+	}
+
+	if objectStorage.Compression != "" && !slices.Contains(supportedObjectStorageCompressions, objectStorage.Compression) {
+		errs = append(errs, field.NotSupported(path.Child("compression"), objectStorage.Compression, supportedObjectStorageCompressions))
+	}
+
+	if objectStorage.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *objectStorage.TLS)...)
+	}
+
+	errs = append(errs, validateBatch(path.Child("batch"), objectStorage.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), objectStorage.Retry)...)
+	return errs
+}
+
+var supportedKafkaEncodings = []telemetryv1alpha1.KafkaEncoding{
+	telemetryv1alpha1.KafkaEncodingJSON,
+	telemetryv1alpha1.KafkaEncodingProtobuf,
+}
+
+var supportedKafkaSASLMechanisms = []telemetryv1alpha1.KafkaSASLMechanism{
+	telemetryv1alpha1.KafkaSASLMechanismScramSHA256,
+	telemetryv1alpha1.KafkaSASLMechanismScramSHA512,
+}
+
+func validateKafka(path *field.Path, kafka telemetryv1alpha1.KafkaSink, signal string) field.ErrorList {
+	var errs field.ErrorList
+	if len(kafka.Brokers) == 0 {
+		errs = append(errs, field.Required(path.Child("brokers"), "At least one broker is required"))
+	}
+	brokers := map[string]struct{}{}
+	for index, broker := range kafka.Brokers {
+		brokerPath := path.Child("brokers").Index(index)
+		if host, port, err := net.SplitHostPort(broker); err != nil || host == "" || port == "" {
+			errs = append(errs, field.Invalid(brokerPath, broker, "Brokers must be in the format host:port"))
+		} else if _, set := brokers[broker]; set {
+			errs = append(errs, field.Duplicate(brokerPath, broker))
+		}
+		brokers[broker] = struct{}{}
+	}
+
+	if kafka.Topic == "" {
+		errs = append(errs, field.Required(path.Child("topic"), "A topic is required"))
+	} else if err := checkTemplate(kafka.Topic); err != nil {
+		errs = append(errs, field.Invalid(path.Child("topic"), kafka.Topic, err.Error()))
+	} else if signal == SignalMetrics {
+		if err := CheckMetricTemplate(kafka.Topic); err != nil {
+			errs = append(errs, field.Invalid(path.Child("topic"), kafka.Topic, err.Error()))
+		}
+	}
+
+	if kafka.SASL != nil {
+		if kafka.SASL.Mechanism != "" && !slices.Contains(supportedKafkaSASLMechanisms, kafka.SASL.Mechanism) {
+			errs = append(errs, field.NotSupported(path.Child("sasl", "mechanism"), kafka.SASL.Mechanism, supportedKafkaSASLMechanisms))
+		}
+		if kafka.SASL.SecretRef.Name == "" {
+			errs = append(errs, field.Required(path.Child("sasl", "secretRef", "name"), "The name of the secret is required"))
+		}
+	}
+
+	if kafka.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *kafka.TLS)...)
+	}
+
+	if kafka.Encoding != "" && !slices.Contains(supportedKafkaEncodings, kafka.Encoding) {
+		errs = append(errs, field.NotSupported(path.Child("encoding"), kafka.Encoding, supportedKafkaEncodings))
+	}
+	return errs
+}
+
+func validateSplunkHEC(path *field.Path, splunk telemetryv1alpha1.SplunkHECSink) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateHTTPEndpoint(path.Child("endpoint"), splunk.Endpoint)...)
+	errs = append(errs, validateLocalSecretKeyReference(path.Child("tokenSecretKeyRef"), splunk.TokenSecretKeyRef)...)
+
+	if err := checkTemplate(splunk.Index); err != nil {
+		errs = append(errs, field.Invalid(path.Child("index"), splunk.Index, err.Error()))
+	}
+
+	if err := checkTemplate(splunk.SourceType); err != nil {
+		errs = append(errs, field.Invalid(path.Child("sourceType"), splunk.SourceType, err.Error()))
+	}
+
+	if splunk.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *splunk.TLS)...)
+	}
+
+	errs = append(errs, validateBatch(path.Child("batch"), splunk.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), splunk.Retry)...)
+	return errs
+}
+
+func validateElasticsearch(path *field.Path, elasticsearch telemetryv1alpha1.ElasticsearchSink) field.ErrorList {
+	var errs field.ErrorList
+	if len(elasticsearch.Endpoints) == 0 {
+		errs = append(errs, field.Required(path.Child("endpoints"), "At least one endpoint is required"))
+	}
+	endpoints := map[string]struct{}{}
+	for index, endpoint := range elasticsearch.Endpoints {
+		endpointPath := path.Child("endpoints").Index(index)
+		if _, set := endpoints[endpoint]; set {
+			errs = append(errs, field.Duplicate(endpointPath, endpoint))
+		} else {
+			errs = append(errs, validateHTTPEndpoint(endpointPath, endpoint)...)
+		}
+		endpoints[endpoint] = struct{}{}
+	}
+
+	if err := checkTemplate(elasticsearch.Index); err != nil {
+		errs = append(errs, field.Invalid(path.Child("index"), elasticsearch.Index, err.Error()))
+	}
+
+	if dataStream := elasticsearch.DataStream; dataStream != nil {
+		dataStreamPath := path.Child("dataStream")
+		if elasticsearch.Index != "" {
+			errs = append(errs, field.Forbidden(path.Child("index"), "An index can not be configured when publishing to a data stream"))
+		}
+		if err := checkTemplate(dataStream.Type); err != nil {
+			errs = append(errs, field.Invalid(dataStreamPath.Child("type"), dataStream.Type, err.Error()))
+		}
+		if err := checkTemplate(dataStream.Dataset); err != nil {
+			errs = append(errs, field.Invalid(dataStreamPath.Child("dataset"), dataStream.Dataset, err.Error()))
+		}
+		if err := checkTemplate(dataStream.Namespace); err != nil {
+			errs = append(errs, field.Invalid(dataStreamPath.Child("namespace"), dataStream.Namespace, err.Error()))
+		}
+	}
+
+	if auth := elasticsearch.Authentication; auth != nil {
+		authPath := path.Child("authentication")
+		var methods []string
+		if auth.BasicAuth != nil {
+			methods = append(methods, "basicAuth")
+			if auth.BasicAuth.SecretRef.Name == "" {
+				errs = append(errs, field.Required(authPath.Child("basicAuth", "secretRef", "name"), "The name of the secret is required"))
+			}
+		}
+		if auth.APIKey != nil {
+			methods = append(methods, "apiKey")
+			errs = append(errs, validateLocalSecretKeyReference(authPath.Child("apiKey", "secretKeyRef"), auth.APIKey.SecretKeyRef)...)
+		}
+
+		if len(methods) == 0 {
+			errs = append(errs, field.Required(authPath, "An authentication method must be configured"))
+		} else if len(methods) > 1 {
+			errs = append(errs, field.Forbidden(authPath, fmt.Sprintf("Only one authentication method can be configured, found: %s", strings.Join(methods, ", "))))
+		}
+	}
+
+	if elasticsearch.TLS != nil {
+		errs = append(errs, validateTLSConfig(path.Child("tls"), *elasticsearch.TLS)...)
+	}
+
+	errs = append(errs, validateBatch(path.Child("batch"), elasticsearch.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), elasticsearch.Retry)...)
+	return errs
+}
+
+var supportedDatadogSites = []telemetryv1alpha1.DatadogSite{
+	telemetryv1alpha1.DatadogSiteUS1,
+	telemetryv1alpha1.DatadogSiteUS3,
+	telemetryv1alpha1.DatadogSiteUS5,
+	telemetryv1alpha1.DatadogSiteEU1,
+	telemetryv1alpha1.DatadogSiteAP1,
+	telemetryv1alpha1.DatadogSiteUS1FED,
+}
+
+func validateDatadogMetrics(path *field.Path, datadog telemetryv1alpha1.DatadogMetricsSink) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateLocalSecretKeyReference(path.Child("apiKeySecretRef"), datadog.APIKeySecretRef)...)
+
+	if !slices.Contains(supportedDatadogSites, datadog.Site) {
+		errs = append(errs, field.NotSupported(path.Child("site"), datadog.Site, supportedDatadogSites))
+	}
+
+	errs = append(errs, validateBatch(path.Child("batch"), datadog.Batch)...)
+	errs = append(errs, validateRetry(path.Child("retry"), datadog.Retry)...)
+	return errs
+}
+
+func validateTLSConfig(path *field.Path, tls telemetryv1alpha1.TLSConfig) field.ErrorList {
+	var errs field.ErrorList
+	if tls.CABundle != nil {
+		caBundlePath := path.Child("caBundle")
+		if tls.CABundle.SecretKeyRef == nil && tls.CABundle.ConfigMapKeyRef == nil {
+			errs = append(errs, field.Required(caBundlePath, "A source for the CA bundle must be configured"))
+		} else if tls.CABundle.SecretKeyRef != nil && tls.CABundle.ConfigMapKeyRef != nil {
+			errs = append(errs, field.Forbidden(caBundlePath, "Only one of secretKeyRef or configMapKeyRef can be configured"))
+		}
+
+		if tls.CABundle.SecretKeyRef != nil {
+			errs = append(errs, validateLocalSecretKeyReference(caBundlePath.Child("secretKeyRef"), *tls.CABundle.SecretKeyRef)...)
+		}
+
+		if ref := tls.CABundle.ConfigMapKeyRef; ref != nil {
+			if ref.Name == "" {
+				errs = append(errs, field.Required(caBundlePath.Child("configMapKeyRef", "name"), "The name of the config map is required"))
+			}
+			if ref.Key == "" {
+				errs = append(errs, field.Required(caBundlePath.Child("configMapKeyRef", "key"), "The key of the config map is required"))
+			}
+		}
+	}
+
+	if tls.ClientCertificate != nil && tls.ClientCertificate.Name == "" {
+		errs = append(errs, field.Required(path.Child("clientCertificate", "name"), "The name of the client certificate secret is required"))
+	}
+
+	if tls.ServerName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(tls.ServerName) {
+			errs = append(errs, field.Invalid(path.Child("serverName"), tls.ServerName, msg))
+		}
+	}
+	return errs
+}
+
+func validateLocalSecretKeyReference(path *field.Path, ref telemetryv1alpha1.LocalSecretKeyReference) field.ErrorList {
+	var errs field.ErrorList
+	if ref.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "The name of the secret is required"))
+	}
+	if ref.Key == "" {
+		errs = append(errs, field.Required(path.Child("key"), "The key of the secret is required"))
+	}
+	return errs
+}
+
+func validateAuthentication(path *field.Path, auth telemetryv1alpha1.Authentication) field.ErrorList {
+	var errs field.ErrorList
+	var methods []string
+	if auth.BasicAuth != nil {
+		methods = append(methods, "basicAuth")
+		if auth.BasicAuth.SecretRef.Name == "" {
+			errs = append(errs, field.Required(path.Child("basicAuth", "secretRef", "name"), "The name of the secret is required"))
+		}
+	}
+
+	if auth.BearerToken != nil {
+		methods = append(methods, "bearerToken")
+		errs = append(errs, validateLocalSecretKeyReference(path.Child("bearerToken", "secretKeyRef"), auth.BearerToken.SecretKeyRef)...)
+	}
+
+	if auth.OAuth2 != nil {
+		methods = append(methods, "oauth2")
+		errs = append(errs, validateOAuth2Authentication(path.Child("oauth2"), *auth.OAuth2)...)
+	}
+
+	if auth.AWSSigV4 != nil {
+		methods = append(methods, "awsSigV4")
+		if auth.AWSSigV4.Region == "" {
+			errs = append(errs, field.Required(path.Child("awsSigV4", "region"), "The AWS region is required"))
+		}
+		if auth.AWSSigV4.Service != "" && auth.AWSSigV4.Service != "aps" {
+			errs = append(errs, field.NotSupported(path.Child("awsSigV4", "service"), auth.AWSSigV4.Service, []string{"aps"}))
+		}
+		if auth.AWSSigV4.SecretRef.Name == "" {
+			errs = append(errs, field.Required(path.Child("awsSigV4", "secretRef", "name"), "The name of the secret is required"))
+		}
+	}
+
+	if len(methods) == 0 {
+		errs = append(errs, field.Required(path, "An authentication method must be configured"))
+	} else if len(methods) > 1 {
+		errs = append(errs, field.Forbidden(path, fmt.Sprintf("Only one authentication method can be configured, found: %s", strings.Join(methods, ", "))))
+	}
+	return errs
+}
+
+func validateOAuth2Authentication(path *field.Path, oauth2 telemetryv1alpha1.OAuth2Authentication) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateOAuth2TokenURL(path.Child("tokenURL"), oauth2.TokenURL)...)
+
+	if oauth2.ClientSecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("clientSecretRef", "name"), "The name of the secret is required"))
+	}
+
+	for index, scope := range oauth2.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\n") {
+			errs = append(errs, field.Invalid(path.Child("scopes").Index(index), scope, "A scope must be a non-empty string without whitespace"))
+		}
+	}
+	return errs
+}
+
+// validateOAuth2TokenURL validates the token URL of an OAuth2 authentication.
+// The control plane requests access tokens from the token URL, so the URL must
+// use https and can't target internal addresses.
+func validateOAuth2TokenURL(path *field.Path, tokenURL string) field.ErrorList {
+	errs := validateHTTPEndpoint(path, tokenURL)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	parsedURL, _ := url.ParseRequestURI(tokenURL)
+	host := strings.ToLower(parsedURL.Hostname())
+	if parsedURL.Scheme != "https" {
+		errs = append(errs, field.Invalid(path, tokenURL, "The token URL must use the https scheme"))
+	} else if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddress(addr) {
+		errs = append(errs, field.Invalid(path, tokenURL, "The token URL can not target a private, loopback or link-local address"))
+	} else if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		errs = append(errs, field.Invalid(path, tokenURL, "The token URL can not target a loopback address"))
+	}
+	return errs
+}
+
+func validateHTTPEndpoint(path *field.Path, endpoint string) field.ErrorList {
+	var errs field.ErrorList
+	if endpoint == "" {
+		errs = append(errs, field.Required(path, "A valid endpoint URL is required"))
+	} else if endpointURL, err := url.ParseRequestURI(endpoint); err != nil {
+		errs = append(errs, field.Invalid(path, endpoint, fmt.Sprintf("Failed to parse URL: %s", err)))
+	} else if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		errs = append(errs, field.Invalid(path, endpoint, "The endpoint URL must use the http or https scheme"))
+	} else if endpointURL.Host == "" {
+		errs = append(errs, field.Invalid(path, endpoint, "The endpoint URL must include a host"))
+	}
+	return errs
+}
+
+func validateHTTPHeaders(path *field.Path, headers []telemetryv1alpha1.HTTPHeader) field.ErrorList {
+	var errs field.ErrorList
+	headerNames := map[string]struct{}{}
+	for index, header := range headers {
+		headerPath := path.Index(index)
+		// HTTP header names are case-insensitive.
+		name := strings.ToLower(header.Name)
+		if header.Name == "" {
+			errs = append(errs, field.Required(headerPath.Child("name"), "A header name is required"))
+		} else if _, set := headerNames[name]; set {
+			errs = append(errs, field.Duplicate(headerPath.Child("name"), header.Name))
+		} else {
+			headerNames[name] = struct{}{}
+		}
+
+		if header.Value != "" && header.SecretKeyRef != nil {
+			errs = append(errs, field.Forbidden(headerPath, "Only one of value or secretKeyRef can be configured"))
+		} else if header.SecretKeyRef != nil {
+			errs = append(errs, validateLocalSecretKeyReference(headerPath.Child("secretKeyRef"), *header.SecretKeyRef)...)
+		}
+	}
+	return errs
+}
+
+func validateBatch(path *field.Path, batch telemetryv1alpha1.Batch) field.ErrorList {
+	var errs field.ErrorList
+	if batch.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), batch.Timeout.Duration.String(), "The batch timeout must be greater than zero"))
+	}
+	return errs
+}
+
+func validateRetry(path *field.Path, retry telemetryv1alpha1.Retry) field.ErrorList {
+	var errs field.ErrorList
+	if backoff := retry.BackoffDuration.Duration; backoff < time.Second || backoff%time.Second != 0 {
+		errs = append(errs, field.Invalid(path.Child("backoffDuration"), backoff.String(), "The backoff duration must be a whole number of seconds"))
+	}
 	return errs
 }
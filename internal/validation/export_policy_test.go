@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	telemetryv1alpha1 "go.datum.net/telemetry-services-operator/api/v1alpha1"
+)
+
+func TestCheckMetricTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{
+			name:  "value without templates",
+			value: "metrics/date=%F/",
+		},
+		{
+			name:  "labels referenced as tags",
+			value: "metrics/{{ tags.resource_name }}/{{tags.resource_kind}}/",
+		},
+		{
+			name:  "metric name and namespace",
+			value: "{{ namespace }}/{{ name }}/",
+		},
+		{
+			name:    "label referenced as a top-level field",
+			value:   "metrics/{{ resource_name }}/",
+			wantErr: "metric labels must be referenced as tags (e.g. {{ tags.resource_name }})",
+		},
+		{
+			name:    "label referenced after a valid template",
+			value:   "{{ tags.resource_name }}/{{ resource_kind }}/",
+			wantErr: "metric labels must be referenced as tags (e.g. {{ tags.resource_kind }})",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMetricTemplate(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateObjectStorageKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyPrefix string
+		signal    string
+		wantErr   bool
+	}{
+		{
+			name:      "metric labels referenced as tags",
+			keyPrefix: "metrics/{{ tags.resource_name }}/",
+			signal:    SignalMetrics,
+		},
+		{
+			name:      "metric label referenced as a top-level field",
+			keyPrefix: "metrics/{{ resource_name }}/",
+			signal:    SignalMetrics,
+			wantErr:   true,
+		},
+		{
+			name:      "log fields referenced by name",
+			keyPrefix: "logs/{{ resource_name }}/",
+			signal:    SignalLogs,
+		},
+		{
+			name:      "unterminated template",
+			keyPrefix: "logs/{{ resource_name /",
+			signal:    SignalLogs,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := field.NewPath("objectStorage")
+			errs := validateObjectStorage(path, telemetryv1alpha1.ObjectStorageSink{
+				Bucket:    "telemetry-archive",
+				KeyPrefix: tt.keyPrefix,
+				Region:    "us-east-1",
+				SecretRef: telemetryv1alpha1.LocalSecretReference{Name: "aws-credentials"},
+				Batch: telemetryv1alpha1.Batch{
+					Timeout: metav1.Duration{Duration: 5 * time.Minute},
+					MaxSize: 5000,
+				},
+				Retry: telemetryv1alpha1.Retry{
+					MaxAttempts:     3,
+					BackoffDuration: metav1.Duration{Duration: 5 * time.Second},
+				},
+			}, tt.signal)
+			if tt.wantErr {
+				if assert.Len(t, errs, 1) {
+					assert.Equal(t, path.Child("keyPrefix").String(), errs[0].Field)
+				}
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestValidateKafkaTopic(t *testing.T) {
+	tests := []struct {
+		name    string
+		topic   string
+		signal  string
+		wantErr bool
+	}{
+		{
+			name:   "metric labels referenced as tags",
+			topic:  "telemetry-{{ tags.resource_kind }}",
+			signal: SignalMetrics,
+		},
+		{
+			name:    "metric label referenced as a top-level field",
+			topic:   "telemetry-{{ resource_kind }}",
+			signal:  SignalMetrics,
+			wantErr: true,
+		},
+		{
+			name:   "metric name",
+			topic:  "telemetry-{{ name }}",
+			signal: SignalMetrics,
+		},
+		{
+			name:   "log fields referenced by name",
+			topic:  "telemetry-{{ resource_kind }}",
+			signal: SignalLogs,
+		},
+		{
+			name:    "unopened template",
+			topic:   "telemetry-resource_kind }}",
+			signal:  SignalLogs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := field.NewPath("kafka")
+			errs := validateKafka(path, telemetryv1alpha1.KafkaSink{
+				Brokers: []string{"kafka-0.example.com:9093"},
+				Topic:   tt.topic,
+			}, tt.signal)
+			if tt.wantErr {
+				if assert.Len(t, errs, 1) {
+					assert.Equal(t, path.Child("topic").String(), errs[0].Field)
+				}
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"errors"
+
+	telemetryv1alpha1 "go.datum.net/telemetry-services-operator/api/v1alpha1"
+)
+
+// The telemetry signals that can be exported by an export policy.
+const (
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+	SignalTraces  = "traces"
+)
+
+// SourceSignal returns the telemetry signal that's produced by the source.
+// Returns an empty string if the source doesn't configure a signal.
+func SourceSignal(source telemetryv1alpha1.TelemetrySource) string {
+	switch {
+	case source.Metrics != nil:
+		return SignalMetrics
+	case source.Logs != nil:
+		return SignalLogs
+	case source.Traces != nil:
+		return SignalTraces
+	}
+	return ""
+}
+
+// SinkTargetSignals returns the telemetry signals that can be published to the
+// sink target.
+func SinkTargetSignals(target telemetryv1alpha1.SinkTarget) []string {
+	switch {
+	case target.OpenTelemetry != nil:
+		return []string{SignalMetrics, SignalTraces}
+	case target.PrometheusRemoteWrite != nil, target.DatadogMetrics != nil:
+		return []string{SignalMetrics}
+	case target.Loki != nil:
+		return []string{SignalLogs}
+	case target.ObjectStorage != nil:
+		return []string{SignalMetrics, SignalLogs}
+	case target.Kafka != nil:
+		return []string{SignalMetrics, SignalLogs}
+	case target.SplunkHEC != nil, target.Elasticsearch != nil:
+		return []string{SignalLogs}
+	}
+	return nil
+}
+
+// CheckLogsQLFilter confirms the LogsQL query only contains filters. Pipes are
+// not supported since they change the shape of the logs that are returned, and
+// parentheses must be balanced so the filter can't escape the filters that are
+// added to restrict the query to the project's logs.
+func CheckLogsQLFilter(query string) error {
+	var depth int
+	var quote rune
+	escaped := false
+	for _, char := range query {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if char == '\\' && quote != '`' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'' || char == '`':
+			quote = char
+		case char == '|':
+			return errors.New("LogsQL pipes are not supported")
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+			if depth < 0 {
+				return errors.New("unbalanced parentheses in LogsQL filter")
+			}
+		}
+	}
+
+	if quote != 0 {
+		return errors.New("unterminated quoted string in LogsQL filter")
+	} else if depth != 0 {
+		return errors.New("unbalanced parentheses in LogsQL filter")
+	}
+	return nil
+}
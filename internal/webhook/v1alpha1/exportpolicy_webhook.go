@@ -54,7 +54,7 @@ func (v *ExportPolicyCustomValidator) ValidateCreate(ctx context.Context, obj ru
 		return nil, errors.NewInvalid(obj.GetObjectKind().GroupVersionKind().GroupKind(), exportpolicy.Name, errs)
 	}
 
-	return nil, nil
+	return validation.ExportPolicyWarnings(exportpolicy), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ExportPolicy.
@@ -69,7 +69,7 @@ func (v *ExportPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj
 		return nil, errors.NewInvalid(newObj.GetObjectKind().GroupVersionKind().GroupKind(), exportpolicy.Name, errs)
 	}
 
-	return nil, nil
+	return validation.ExportPolicyWarnings(exportpolicy), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ExportPolicy.